@@ -2,11 +2,17 @@ package ast
 
 import (
 	"bytes"
+
+	"github.com/rodmedeiross/monkey-interpreter/token"
 )
 
+// Node is implemented by every AST node. Pos is the position of the first
+// character of the node and End the position immediately after its last one.
 type Node interface {
 	String() string
 	TokenLiteral() string
+	Pos() token.Position
+	End() token.Position
 }
 
 type Statement interface {
@@ -31,6 +37,22 @@ func (p *Program) TokenLiteral() string {
 	}
 }
 
+func (p *Program) Pos() token.Position {
+	if len(p.Statements) > 0 {
+		return p.Statements[0].Pos()
+	}
+
+	return token.Position{}
+}
+
+func (p *Program) End() token.Position {
+	if len(p.Statements) > 0 {
+		return p.Statements[len(p.Statements)-1].End()
+	}
+
+	return token.Position{}
+}
+
 func (p *Program) String() string {
 	var out bytes.Buffer
 
@@ -10,10 +10,13 @@ import (
 type ArrayExpression struct {
 	Token  token.Token
 	Values []Expression
+	EndPos token.Position
 }
 
 func (ae *ArrayExpression) expressionNode()      {}
 func (ae *ArrayExpression) TokenLiteral() string { return ae.Token.Literal }
+func (ae *ArrayExpression) Pos() token.Position  { return ae.Token.Start }
+func (ae *ArrayExpression) End() token.Position  { return ae.EndPos }
 func (ae *ArrayExpression) String() string {
 	var out bytes.Buffer
 
@@ -6,19 +6,39 @@ import (
 	"github.com/rodmedeiross/monkey-interpreter/token"
 )
 
+// IndexExpression is left[index]. Optional marks left?[index], which yields null
+// instead of failing when left is null.
 type IndexExpression struct {
-	Token token.Token
-	Left  Expression
-	Index Expression
+	Token    token.Token
+	Left     Expression
+	Index    Expression
+	Optional bool
+	EndPos   token.Position
 }
 
 func (ai *IndexExpression) expressionNode()      {}
 func (ai *IndexExpression) TokenLiteral() string { return ai.Token.Literal }
+
+func (ai *IndexExpression) Pos() token.Position {
+	if ai.Left != nil {
+		return ai.Left.Pos()
+	}
+
+	return ai.Token.Start
+}
+
+func (ai *IndexExpression) End() token.Position { return ai.EndPos }
+
 func (ai *IndexExpression) String() string {
 	var out bytes.Buffer
 
 	out.WriteString("(")
 	out.WriteString(ai.Left.String())
+
+	if ai.Optional {
+		out.WriteString("?")
+	}
+
 	out.WriteString("[")
 	out.WriteString(ai.Index.String())
 	out.WriteString("])")
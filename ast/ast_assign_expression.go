@@ -0,0 +1,43 @@
+package ast
+
+import (
+	"bytes"
+
+	"github.com/rodmedeiross/monkey-interpreter/token"
+)
+
+// AssignExpression rebinds Target to Value. Target is an *Identifier, an
+// *IndexExpression or a *MemberExpression. Operator is "=" or a compound
+// assignment operator such as "+=".
+type AssignExpression struct {
+	Token    token.Token
+	Target   Expression
+	Operator string
+	Value    Expression
+	EndPos   token.Position
+}
+
+func (ae *AssignExpression) expressionNode()      {}
+func (ae *AssignExpression) TokenLiteral() string { return ae.Token.Literal }
+
+func (ae *AssignExpression) Pos() token.Position {
+	if ae.Target != nil {
+		return ae.Target.Pos()
+	}
+
+	return ae.Token.Start
+}
+
+func (ae *AssignExpression) End() token.Position { return ae.EndPos }
+
+func (ae *AssignExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("(")
+	out.WriteString(ae.Target.String())
+	out.WriteString(" " + ae.Operator + " ")
+	out.WriteString(ae.Value.String())
+	out.WriteString(")")
+
+	return out.String()
+}
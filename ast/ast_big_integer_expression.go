@@ -0,0 +1,20 @@
+package ast
+
+import (
+	"math/big"
+
+	"github.com/rodmedeiross/monkey-interpreter/token"
+)
+
+// BigIntegerExpression is an integer literal too large for an
+// IntegerExpression.
+type BigIntegerExpression struct {
+	Token token.Token
+	Value *big.Int
+}
+
+func (bi *BigIntegerExpression) expressionNode()      {}
+func (bi *BigIntegerExpression) TokenLiteral() string { return bi.Token.Literal }
+func (bi *BigIntegerExpression) Pos() token.Position  { return bi.Token.Start }
+func (bi *BigIntegerExpression) End() token.Position  { return bi.Token.End }
+func (bi *BigIntegerExpression) String() string       { return bi.Token.Literal }
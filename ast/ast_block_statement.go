@@ -9,10 +9,13 @@ import (
 type BlockStatement struct {
 	Token      token.Token
 	Statements []Statement
+	EndPos     token.Position
 }
 
 func (bs *BlockStatement) statementNode()       {}
 func (bs *BlockStatement) TokenLiteral() string { return bs.Token.Literal }
+func (bs *BlockStatement) Pos() token.Position  { return bs.Token.Start }
+func (bs *BlockStatement) End() token.Position  { return bs.EndPos }
 func (bs *BlockStatement) String() string {
 	var out bytes.Buffer
 
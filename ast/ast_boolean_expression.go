@@ -11,4 +11,6 @@ type BooleanExpression struct {
 
 func (be *BooleanExpression) expressionNode()      {}
 func (be *BooleanExpression) TokenLiteral() string { return be.Token.Literal }
+func (be *BooleanExpression) Pos() token.Position  { return be.Token.Start }
+func (be *BooleanExpression) End() token.Position  { return be.Token.End }
 func (be *BooleanExpression) String() string       { return be.Token.Literal }
@@ -0,0 +1,14 @@
+package ast
+
+import "github.com/rodmedeiross/monkey-interpreter/token"
+
+type BreakStatement struct {
+	Token  token.Token
+	EndPos token.Position
+}
+
+func (bs *BreakStatement) statementNode()       {}
+func (bs *BreakStatement) TokenLiteral() string { return bs.Token.Literal }
+func (bs *BreakStatement) Pos() token.Position  { return bs.Token.Start }
+func (bs *BreakStatement) End() token.Position  { return bs.EndPos }
+func (bs *BreakStatement) String() string       { return bs.TokenLiteral() + ";" }
@@ -0,0 +1,14 @@
+package ast
+
+import "github.com/rodmedeiross/monkey-interpreter/token"
+
+type ContinueStatement struct {
+	Token  token.Token
+	EndPos token.Position
+}
+
+func (cs *ContinueStatement) statementNode()       {}
+func (cs *ContinueStatement) TokenLiteral() string { return cs.Token.Literal }
+func (cs *ContinueStatement) Pos() token.Position  { return cs.Token.Start }
+func (cs *ContinueStatement) End() token.Position  { return cs.EndPos }
+func (cs *ContinueStatement) String() string       { return cs.TokenLiteral() + ";" }
@@ -5,11 +5,14 @@ import "github.com/rodmedeiross/monkey-interpreter/token"
 type ExpressionStatement struct {
 	Token      token.Token
 	Expression Expression
+	EndPos     token.Position
 }
 
 func (es *ExpressionStatement) statementNode() {}
 
 func (es *ExpressionStatement) TokenLiteral() string { return es.Token.Literal }
+func (es *ExpressionStatement) Pos() token.Position  { return es.Token.Start }
+func (es *ExpressionStatement) End() token.Position  { return es.EndPos }
 
 func (es *ExpressionStatement) String() string {
 	if es.Expression != nil {
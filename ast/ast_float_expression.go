@@ -0,0 +1,14 @@
+package ast
+
+import "github.com/rodmedeiross/monkey-interpreter/token"
+
+type FloatExpression struct {
+	Token token.Token
+	Value float64
+}
+
+func (fe *FloatExpression) expressionNode()      {}
+func (fe *FloatExpression) TokenLiteral() string { return fe.Token.Literal }
+func (fe *FloatExpression) Pos() token.Position  { return fe.Token.Start }
+func (fe *FloatExpression) End() token.Position  { return fe.Token.End }
+func (fe *FloatExpression) String() string       { return fe.Token.Literal }
@@ -0,0 +1,31 @@
+package ast
+
+import (
+	"bytes"
+
+	"github.com/rodmedeiross/monkey-interpreter/token"
+)
+
+type ForStatement struct {
+	Token    token.Token
+	Variable *Identifier
+	Iterable Expression
+	Body     *BlockStatement
+	EndPos   token.Position
+}
+
+func (fs *ForStatement) statementNode()       {}
+func (fs *ForStatement) TokenLiteral() string { return fs.Token.Literal }
+func (fs *ForStatement) Pos() token.Position  { return fs.Token.Start }
+func (fs *ForStatement) End() token.Position  { return fs.EndPos }
+
+func (fs *ForStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("for ")
+	out.WriteString("(" + fs.Variable.String() + " in " + fs.Iterable.String() + ")")
+	out.WriteString(" ")
+	out.WriteString("(" + fs.Body.String() + ")")
+
+	return out.String()
+}
@@ -11,11 +11,22 @@ type CallExpression struct {
 	Token                  token.Token
 	Function               Expression
 	FunctionCallParameters []Expression
+	EndPos                 token.Position
 }
 
 func (ce *CallExpression) expressionNode()      {}
 func (ce *CallExpression) TokenLiteral() string { return ce.Token.Literal }
 
+func (ce *CallExpression) Pos() token.Position {
+	if ce.Function != nil {
+		return ce.Function.Pos()
+	}
+
+	return ce.Token.Start
+}
+
+func (ce *CallExpression) End() token.Position { return ce.EndPos }
+
 func (ce *CallExpression) String() string {
 	var out bytes.Buffer
 
@@ -9,12 +9,16 @@ import (
 
 type FunctionExpression struct {
 	Token      token.Token
+	Name       string // Set when the function is bound with let, used in stack traces
 	Parameters []*Identifier
 	Body       *BlockStatement
+	EndPos     token.Position
 }
 
 func (fe *FunctionExpression) expressionNode()      {}
 func (fe *FunctionExpression) TokenLiteral() string { return fe.Token.Literal }
+func (fe *FunctionExpression) Pos() token.Position  { return fe.Token.Start }
+func (fe *FunctionExpression) End() token.Position  { return fe.EndPos }
 func (fe *FunctionExpression) String() string {
 	var out bytes.Buffer
 
@@ -8,22 +8,32 @@ import (
 	"github.com/rodmedeiross/monkey-interpreter/token"
 )
 
+type HashPair struct {
+	Key   Expression
+	Value Expression
+}
+
+// HashExpression keeps its pairs in source order, which is also the order
+// they are evaluated in.
 type HashExpression struct {
-	Token token.Token
-	Pairs map[Expression]Expression
+	Token  token.Token
+	Pairs  []HashPair
+	EndPos token.Position
 }
 
 func (he *HashExpression) expressionNode() {}
 
 func (he *HashExpression) TokenLiteral() string { return he.Token.Literal }
+func (he *HashExpression) Pos() token.Position  { return he.Token.Start }
+func (he *HashExpression) End() token.Position  { return he.EndPos }
 
 func (he *HashExpression) String() string {
 	var out bytes.Buffer
 
 	pairs := []string{}
 
-	for k, v := range he.Pairs {
-		pairs = append(pairs, fmt.Sprintf("%s:%s", k.String(), v.String()))
+	for _, pair := range he.Pairs {
+		pairs = append(pairs, fmt.Sprintf("%s:%s", pair.Key.String(), pair.Value.String()))
 	}
 
 	out.WriteString("{")
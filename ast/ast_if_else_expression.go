@@ -11,10 +11,13 @@ type IfExpression struct {
 	Conditional Expression
 	Consequence *BlockStatement
 	Alternative *BlockStatement
+	EndPos      token.Position
 }
 
 func (ie *IfExpression) expressionNode()      {}
 func (ie *IfExpression) TokenLiteral() string { return ie.Token.Literal }
+func (ie *IfExpression) Pos() token.Position  { return ie.Token.Start }
+func (ie *IfExpression) End() token.Position  { return ie.EndPos }
 
 func (ie *IfExpression) String() string {
 	var out bytes.Buffer
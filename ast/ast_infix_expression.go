@@ -11,11 +11,22 @@ type InfixExpression struct {
 	Left     Expression
 	Operator string
 	Right    Expression
+	EndPos   token.Position
 }
 
 func (ie *InfixExpression) expressionNode()      {}
 func (ie *InfixExpression) TokenLiteral() string { return ie.Token.Literal }
 
+func (ie *InfixExpression) Pos() token.Position {
+	if ie.Left != nil {
+		return ie.Left.Pos()
+	}
+
+	return ie.Token.Start
+}
+
+func (ie *InfixExpression) End() token.Position { return ie.EndPos }
+
 func (ie *InfixExpression) String() string {
 	var out bytes.Buffer
 
@@ -9,4 +9,6 @@ type IntegerExpression struct {
 
 func (ie *IntegerExpression) expressionNode()      {}
 func (ie *IntegerExpression) TokenLiteral() string { return ie.Token.Literal }
+func (ie *IntegerExpression) Pos() token.Position  { return ie.Token.Start }
+func (ie *IntegerExpression) End() token.Position  { return ie.Token.End }
 func (ie *IntegerExpression) String() string       { return ie.Token.Literal }
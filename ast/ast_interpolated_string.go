@@ -0,0 +1,38 @@
+package ast
+
+import (
+	"bytes"
+
+	"github.com/rodmedeiross/monkey-interpreter/token"
+)
+
+// InterpolatedString is a string literal with embedded expressions, as in
+// "Hello ${name}". Parts alternate between text, as StringExpressions, and the
+// embedded expressions, starting and ending with text, which may be empty.
+type InterpolatedString struct {
+	Token  token.Token // The TEMPLATE_HEAD token
+	Parts  []Expression
+	EndPos token.Position
+}
+
+func (is *InterpolatedString) expressionNode()      {}
+func (is *InterpolatedString) TokenLiteral() string { return is.Token.Literal }
+func (is *InterpolatedString) Pos() token.Position  { return is.Token.Start }
+func (is *InterpolatedString) End() token.Position  { return is.EndPos }
+
+func (is *InterpolatedString) String() string {
+	var out bytes.Buffer
+
+	for i, part := range is.Parts {
+		if i%2 == 0 {
+			out.WriteString(part.String())
+			continue
+		}
+
+		out.WriteString("${")
+		out.WriteString(part.String())
+		out.WriteString("}")
+	}
+
+	return out.String()
+}
@@ -7,9 +7,10 @@ import (
 )
 
 type LetStatement struct {
-	Token token.Token
-	Name  *Identifier
-	Value Expression
+	Token  token.Token
+	Name   *Identifier
+	Value  Expression
+	EndPos token.Position
 }
 
 func (ls *LetStatement) statementNode() {}
@@ -18,6 +19,9 @@ func (ls *LetStatement) TokenLiteral() string {
 	return ls.Token.Literal
 }
 
+func (ls *LetStatement) Pos() token.Position { return ls.Token.Start }
+func (ls *LetStatement) End() token.Position { return ls.EndPos }
+
 func (ls *LetStatement) String() string {
 	var out bytes.Buffer
 
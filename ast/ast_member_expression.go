@@ -0,0 +1,43 @@
+package ast
+
+import (
+	"bytes"
+
+	"github.com/rodmedeiross/monkey-interpreter/token"
+)
+
+// MemberExpression is object.property. On a hash it reads the "property" key;
+// as the callee of a call it is a method call. Optional marks object?.property,
+// which yields null instead of failing when object is null.
+type MemberExpression struct {
+	Token    token.Token
+	Object   Expression
+	Property *Identifier
+	Optional bool
+	EndPos   token.Position
+}
+
+func (me *MemberExpression) expressionNode()      {}
+func (me *MemberExpression) TokenLiteral() string { return me.Token.Literal }
+
+func (me *MemberExpression) Pos() token.Position {
+	if me.Object != nil {
+		return me.Object.Pos()
+	}
+
+	return me.Token.Start
+}
+
+func (me *MemberExpression) End() token.Position { return me.EndPos }
+
+func (me *MemberExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("(")
+	out.WriteString(me.Object.String())
+	out.WriteString(me.Token.Literal)
+	out.WriteString(me.Property.String())
+	out.WriteString(")")
+
+	return out.String()
+}
@@ -0,0 +1,15 @@
+package ast
+
+import (
+	"github.com/rodmedeiross/monkey-interpreter/token"
+)
+
+type NullExpression struct {
+	Token token.Token
+}
+
+func (ne *NullExpression) expressionNode()      {}
+func (ne *NullExpression) TokenLiteral() string { return ne.Token.Literal }
+func (ne *NullExpression) Pos() token.Position  { return ne.Token.Start }
+func (ne *NullExpression) End() token.Position  { return ne.Token.End }
+func (ne *NullExpression) String() string       { return ne.Token.Literal }
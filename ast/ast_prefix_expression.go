@@ -10,10 +10,13 @@ type PrefixExpression struct {
 	Token    token.Token
 	Operator string
 	Right    Expression
+	EndPos   token.Position
 }
 
 func (pe *PrefixExpression) expressionNode()      {}
 func (pe *PrefixExpression) TokenLiteral() string { return pe.Token.Literal }
+func (pe *PrefixExpression) Pos() token.Position  { return pe.Token.Start }
+func (pe *PrefixExpression) End() token.Position  { return pe.EndPos }
 
 func (pe *PrefixExpression) String() string {
 	var out bytes.Buffer
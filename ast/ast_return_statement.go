@@ -7,8 +7,9 @@ import (
 )
 
 type ReturnStatement struct {
-	Token token.Token
-	Value Expression
+	Token  token.Token
+	Value  Expression
+	EndPos token.Position
 }
 
 func (rs *ReturnStatement) statementNode() {}
@@ -17,6 +18,9 @@ func (rs *ReturnStatement) TokenLiteral() string {
 	return rs.Token.Literal
 }
 
+func (rs *ReturnStatement) Pos() token.Position { return rs.Token.Start }
+func (rs *ReturnStatement) End() token.Position { return rs.EndPos }
+
 func (rs *ReturnStatement) String() string {
 	var out bytes.Buffer
 
@@ -0,0 +1,59 @@
+package ast
+
+import (
+	"bytes"
+
+	"github.com/rodmedeiross/monkey-interpreter/token"
+)
+
+// SliceExpression is left[low:high]. Low and High are nil when omitted, as in
+// left[:high] and left[low:]. Optional marks left?[low:high], which yields null
+// instead of failing when left is null.
+type SliceExpression struct {
+	Token    token.Token
+	Left     Expression
+	Low      Expression
+	High     Expression
+	Optional bool
+	EndPos   token.Position
+}
+
+func (se *SliceExpression) expressionNode()      {}
+func (se *SliceExpression) TokenLiteral() string { return se.Token.Literal }
+
+func (se *SliceExpression) Pos() token.Position {
+	if se.Left != nil {
+		return se.Left.Pos()
+	}
+
+	return se.Token.Start
+}
+
+func (se *SliceExpression) End() token.Position { return se.EndPos }
+
+func (se *SliceExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("(")
+	out.WriteString(se.Left.String())
+
+	if se.Optional {
+		out.WriteString("?")
+	}
+
+	out.WriteString("[")
+
+	if se.Low != nil {
+		out.WriteString(se.Low.String())
+	}
+
+	out.WriteString(":")
+
+	if se.High != nil {
+		out.WriteString(se.High.String())
+	}
+
+	out.WriteString("])")
+
+	return out.String()
+}
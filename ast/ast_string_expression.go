@@ -9,4 +9,6 @@ type StringExpression struct {
 
 func (se *StringExpression) expressionNode()      {}
 func (se *StringExpression) TokenLiteral() string { return se.Token.Literal }
+func (se *StringExpression) Pos() token.Position  { return se.Token.Start }
+func (se *StringExpression) End() token.Position  { return se.Token.End }
 func (se *StringExpression) String() string       { return se.Token.Literal }
@@ -0,0 +1,30 @@
+package ast
+
+import (
+	"bytes"
+
+	"github.com/rodmedeiross/monkey-interpreter/token"
+)
+
+type WhileStatement struct {
+	Token     token.Token
+	Condition Expression
+	Body      *BlockStatement
+	EndPos    token.Position
+}
+
+func (ws *WhileStatement) statementNode()       {}
+func (ws *WhileStatement) TokenLiteral() string { return ws.Token.Literal }
+func (ws *WhileStatement) Pos() token.Position  { return ws.Token.Start }
+func (ws *WhileStatement) End() token.Position  { return ws.EndPos }
+
+func (ws *WhileStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("while ")
+	out.WriteString("(" + ws.Condition.String() + ")")
+	out.WriteString(" ")
+	out.WriteString("(" + ws.Body.String() + ")")
+
+	return out.String()
+}
@@ -0,0 +1,49 @@
+package diagnostics
+
+import (
+	"fmt"
+
+	"github.com/rodmedeiross/monkey-interpreter/token"
+)
+
+type Severity int
+
+const (
+	ERROR Severity = iota
+	WARNING
+	NOTE
+)
+
+func (s Severity) String() string {
+	switch s {
+	case ERROR:
+		return "error"
+	case WARNING:
+		return "warning"
+	case NOTE:
+		return "note"
+	default:
+		return "unknown"
+	}
+}
+
+// Span is the region of source a diagnostic refers to. End points to the
+// character immediately after the region.
+type Span struct {
+	Start token.Position
+	End   token.Position
+}
+
+// Diagnostic is a message about the source emitted by the parser or the
+// evaluator. Code is a short identifier such as "P0001" and may be empty.
+type Diagnostic struct {
+	Severity Severity
+	Code     string
+	Span     Span
+	Message  string
+	Notes    []string
+}
+
+func (d *Diagnostic) Error() string {
+	return fmt.Sprintf("%s: %s", d.Span.Start, d.Message)
+}
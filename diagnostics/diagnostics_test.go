@@ -0,0 +1,157 @@
+package diagnostics
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rodmedeiross/monkey-interpreter/token"
+)
+
+func TestRender(t *testing.T) {
+	source := "let x = 5;\nlet y 2;\n\tx + true;\nlet z = fn(a) {\n  a\n};"
+
+	tests := []struct {
+		diagnostic *Diagnostic
+		expected   string
+	}{
+		{
+			&Diagnostic{
+				Severity: ERROR,
+				Code:     "P0001",
+				Span:     span(2, 7, 2, 8),
+				Message:  "unexpected token",
+			},
+			"error[P0001]: unexpected token\n" +
+				" --> 2:7\n" +
+				"  |\n" +
+				"2 | let y 2;\n" +
+				"  |       ^\n",
+		},
+		{
+			&Diagnostic{
+				Severity: ERROR,
+				Span:     span(3, 2, 3, 10),
+				Message:  "type mismatch: INTEGER + BOOLEAN",
+				Notes:    []string{"first note", "second note"},
+			},
+			"error: type mismatch: INTEGER + BOOLEAN\n" +
+				" --> 3:2\n" +
+				"  |\n" +
+				"3 | \tx + true;\n" +
+				"  | \t^^^^^^^^\n" +
+				"  = note: first note\n" +
+				"  = note: second note\n",
+		},
+		{
+			&Diagnostic{
+				Severity: WARNING,
+				Span:     span(4, 9, 6, 2),
+				Message:  "multi-line span",
+			},
+			"warning: multi-line span\n" +
+				" --> 4:9\n" +
+				"  |\n" +
+				"4 | let z = fn(a) {\n" +
+				"  |         ^^^^^^^\n",
+		},
+		{
+			&Diagnostic{
+				Severity: NOTE,
+				Span:     span(1, 1, 0, 0),
+				Message:  "no end position",
+			},
+			"note: no end position\n" +
+				" --> 1:1\n" +
+				"  |\n" +
+				"1 | let x = 5;\n" +
+				"  | ^\n",
+		},
+		{
+			&Diagnostic{
+				Severity: ERROR,
+				Message:  "no position",
+			},
+			"error: no position\n",
+		},
+		{
+			&Diagnostic{
+				Severity: ERROR,
+				Span:     span(42, 1, 42, 2),
+				Message:  "line out of range",
+			},
+			"error: line out of range\n" +
+				"  --> 42:1\n",
+		},
+	}
+
+	for i, tt := range tests {
+		var out bytes.Buffer
+
+		Render(&out, source, tt.diagnostic)
+
+		if out.String() != tt.expected {
+			t.Errorf("tests[%d] - wrong output.\nexpected=\n%s\ngot=\n%s", i, tt.expected, out.String())
+		}
+	}
+}
+
+func TestRenderMultiByteLine(t *testing.T) {
+	source := `let saudação = "olá" + true;`
+
+	d := &Diagnostic{
+		Severity: ERROR,
+		Span:     span(1, 16, 1, 28),
+		Message:  "type mismatch: STRING_OBJ + BOOLEAN",
+	}
+
+	expected := "error: type mismatch: STRING_OBJ + BOOLEAN\n" +
+		" --> 1:16\n" +
+		"  |\n" +
+		"1 | let saudação = \"olá\" + true;\n" +
+		"  |                ^^^^^^^^^^^^\n"
+
+	var out bytes.Buffer
+
+	Render(&out, source, d)
+
+	if out.String() != expected {
+		t.Errorf("wrong output.\nexpected=\n%s\ngot=\n%s", expected, out.String())
+	}
+}
+
+func TestRenderWithoutSource(t *testing.T) {
+	d := &Diagnostic{
+		Severity: ERROR,
+		Span:     span(1, 5, 1, 9),
+		Message:  "type mismatch: INTEGER + BOOLEAN",
+	}
+
+	expected := "error: type mismatch: INTEGER + BOOLEAN\n" +
+		" --> 1:5\n"
+
+	var out bytes.Buffer
+
+	Render(&out, "", d)
+
+	if out.String() != expected {
+		t.Errorf("wrong output.\nexpected=\n%s\ngot=\n%s", expected, out.String())
+	}
+}
+
+func TestDiagnosticError(t *testing.T) {
+	d := &Diagnostic{
+		Span:    Span{Start: token.Position{Filename: "script.mk", Line: 12, Column: 7}},
+		Message: "identifier not found: foo",
+	}
+
+	if d.Error() != "script.mk:12:7: identifier not found: foo" {
+		t.Errorf("d.Error() wrong, got=%q", d.Error())
+	}
+}
+
+func span(startLine, startColumn, endLine, endColumn int) Span {
+	return Span{
+		Start: token.Position{Line: startLine, Column: startColumn},
+		End:   token.Position{Line: endLine, Column: endColumn},
+	}
+}
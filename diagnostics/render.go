@@ -0,0 +1,101 @@
+package diagnostics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Render writes d to out in a rustc-like layout, quoting the offending line of
+// source and underlining the span with carets. Notes follow as "= note:" lines.
+// The excerpt is left out when source is empty or has no such line.
+//
+//	error: type mismatch: INTEGER + BOOLEAN
+//	 --> script.mk:2:9
+//	  |
+//	2 | let y = x + true;
+//	  |         ^^^^^^^^
+func Render(out io.Writer, source string, d *Diagnostic) {
+	var buf bytes.Buffer
+
+	buf.WriteString(d.Severity.String())
+	if d.Code != "" {
+		buf.WriteString("[" + d.Code + "]")
+	}
+	buf.WriteString(": " + d.Message + "\n")
+
+	start := d.Span.Start
+	line, ok := sourceLine(source, start.Line)
+
+	gutter := strings.Repeat(" ", len(strconv.Itoa(start.Line)))
+
+	if start.IsValid() {
+		buf.WriteString(fmt.Sprintf("%s--> %s\n", gutter, start))
+	}
+
+	if ok {
+		buf.WriteString(gutter + " |\n")
+		buf.WriteString(fmt.Sprintf("%d | %s\n", start.Line, line))
+		buf.WriteString(gutter + " | " + underline(line, d.Span) + "\n")
+	}
+
+	for _, note := range d.Notes {
+		buf.WriteString(gutter + " = note: " + note + "\n")
+	}
+
+	out.Write(buf.Bytes())
+}
+
+func sourceLine(source string, line int) (string, bool) {
+	if line <= 0 || source == "" {
+		return "", false
+	}
+
+	lines := strings.Split(source, "\n")
+
+	if line > len(lines) {
+		return "", false
+	}
+
+	return strings.TrimRight(lines[line-1], "\r"), true
+}
+
+// underline returns the caret marker for span on line. Columns count runes, so
+// the line is indexed by rune. Tabs before the span are kept so the carets line
+// up with the quoted source. A span that continues on following lines is
+// underlined to the end of line.
+func underline(line string, span Span) string {
+	runes := []rune(line)
+
+	from := span.Start.Column - 1
+	if from > len(runes) {
+		from = len(runes)
+	}
+
+	to := from + 1
+	if span.End.Line == span.Start.Line && span.End.Column > span.Start.Column {
+		to = span.End.Column - 1
+	} else if span.End.Line > span.Start.Line {
+		to = len(runes)
+	}
+
+	if to <= from {
+		to = from + 1
+	}
+
+	var out bytes.Buffer
+
+	for _, ch := range runes[:from] {
+		if ch == '\t' {
+			out.WriteByte('\t')
+		} else {
+			out.WriteByte(' ')
+		}
+	}
+
+	out.WriteString(strings.Repeat("^", to-from))
+
+	return out.String()
+}
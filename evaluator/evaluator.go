@@ -2,7 +2,10 @@ package evaluator
 
 import (
 	"fmt"
-	"strconv"
+	"math"
+	"math/big"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/rodmedeiross/monkey-interpreter/ast"
 	"github.com/rodmedeiross/monkey-interpreter/object"
@@ -10,11 +13,17 @@ import (
 )
 
 var (
-	TRUE  = &object.Boolean{Value: true}
-	FALSE = &object.Boolean{Value: false}
-	NULL  = &object.Null{}
+	TRUE     = &object.Boolean{Value: true}
+	FALSE    = &object.Boolean{Value: false}
+	NULL     = &object.Null{}
+	BREAK    = &object.Break{}
+	CONTINUE = &object.Continue{}
 )
 
+// maxIntegerBits bounds the size of the integers that shifts and powers may
+// produce, so that a single expression cannot exhaust memory or run for ages.
+const maxIntegerBits = 1 << 20
+
 var builtInFunctions = map[string]*object.BuiltIn{
 	"len": {
 		Fn: func(args ...object.Object) object.Object {
@@ -24,7 +33,7 @@ var builtInFunctions = map[string]*object.BuiltIn{
 
 			switch arg := args[0].(type) {
 			case *object.String:
-				return &object.Integer{Value: int64(len(arg.Value))}
+				return &object.Integer{Value: int64(utf8.RuneCountInString(arg.Value))}
 			case *object.Array:
 				return &object.Integer{Value: int64(len(arg.Elements))}
 			default:
@@ -39,17 +48,23 @@ var builtInFunctions = map[string]*object.BuiltIn{
 				return setError("wrong number of arguments, got=%d, want=1", len(args))
 			}
 
-			if args[0].Type() != object.ARRAY_OBJ {
-				return setError("argument to 'first' is not supported, got=%s", args[0].Type())
-			}
+			switch arg := args[0].(type) {
+			case *object.String:
+				if arg.Value == "" {
+					return NULL
+				}
 
-			arr := args[0].(*object.Array).Elements
+				ch, _ := utf8.DecodeRuneInString(arg.Value)
+				return &object.String{Value: string(ch)}
+			case *object.Array:
+				if len(arg.Elements) > 0 {
+					return arg.Elements[0]
+				}
 
-			if len(arr) > 0 {
-				return arr[0]
+				return NULL
+			default:
+				return setError("argument to 'first' is not supported, got=%s", arg.Type())
 			}
-
-			return NULL
 		},
 	},
 	"rest": {
@@ -58,19 +73,28 @@ var builtInFunctions = map[string]*object.BuiltIn{
 				return setError("wrong number of arguments, got=%d, want=1", len(args))
 			}
 
-			if args[0].Type() != object.ARRAY_OBJ {
-				return setError("argument to 'rest' is not supported, got=%s", args[0].Type())
-			}
+			switch arg := args[0].(type) {
+			case *object.String:
+				if arg.Value == "" {
+					return NULL
+				}
 
-			arr := args[0].(*object.Array).Elements
+				_, size := utf8.DecodeRuneInString(arg.Value)
+				return &object.String{Value: arg.Value[size:]}
+			case *object.Array:
+				if l := len(arg.Elements); l > 0 {
+					newElements := make([]object.Object, l-1)
+					copy(newElements, arg.Elements[1:l])
 
-			if l := len(arr); l > 0 {
-				return &object.Array{
-					Elements: arr[1:l],
+					return &object.Array{
+						Elements: newElements,
+					}
 				}
-			}
 
-			return NULL
+				return NULL
+			default:
+				return setError("argument to 'rest' is not supported, got=%s", arg.Type())
+			}
 		},
 	},
 	"push": {
@@ -107,22 +131,57 @@ var builtInFunctions = map[string]*object.BuiltIn{
 	},
 }
 
+// Eval evaluates node in env. Errors raised while evaluating node are tagged
+// with the position of the innermost node that produced them.
 func Eval(node ast.Node, env *object.Environment) object.Object {
+	obj := evalNode(node, env)
+
+	if err, ok := obj.(*object.Error); ok && !err.Pos.IsValid() && node != nil {
+		err.Pos = node.Pos()
+		err.End = node.End()
+	}
+
+	return obj
+}
+
+func evalNode(node ast.Node, env *object.Environment) object.Object {
 	switch node := node.(type) {
 	case *ast.IntegerExpression:
 		return &object.Integer{
 			Value: node.Value,
 		}
+	case *ast.BigIntegerExpression:
+		return &object.BigInt{
+			Value: node.Value,
+		}
+	case *ast.FloatExpression:
+		return &object.Float{
+			Value: node.Value,
+		}
 	case *ast.StringExpression:
-		str, err := strconv.Unquote(`"` + node.Value + `"`)
-		if err != nil {
-			setError("string evaluation error: %s", err)
+		return &object.String{
+			Value: node.Value,
 		}
+	case *ast.InterpolatedString:
+		var out strings.Builder
+
+		for _, part := range node.Parts {
+			obj := Eval(part, env)
+
+			if isAbrupt(obj) {
+				return obj
+			}
+
+			out.WriteString(obj.Inspect())
+		}
+
 		return &object.String{
-			Value: str,
+			Value: out.String(),
 		}
 	case *ast.BooleanExpression:
 		return nativeBoolToBooleanObj(node.Value)
+	case *ast.NullExpression:
+		return NULL
 	case *ast.Program:
 		return func(node *ast.Program) object.Object {
 			var obj object.Object
@@ -143,7 +202,7 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 	case *ast.LetStatement:
 		val := Eval(node.Value, env)
 
-		if isError(val) {
+		if isAbrupt(val) {
 			return val
 		}
 
@@ -166,7 +225,7 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 	case *ast.ReturnStatement:
 		val := Eval(node.Value, env)
 
-		if isError(val) {
+		if isAbrupt(val) {
 			return val
 		}
 
@@ -181,7 +240,7 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 				if obj != nil {
 					oty := obj.Type()
 
-					if oty == object.RETURN_OBJ || oty == object.ERROR_OBJ {
+					if oty == object.RETURN_OBJ || oty == object.ERROR_OBJ || oty == object.BREAK_OBJ || oty == object.CONTINUE_OBJ {
 						return obj
 					}
 				}
@@ -191,11 +250,23 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 			return obj
 		}(node)
 
+	case *ast.WhileStatement:
+		return evalWhileStatement(node, env)
+
+	case *ast.ForStatement:
+		return evalForStatement(node, env)
+
+	case *ast.BreakStatement:
+		return BREAK
+
+	case *ast.ContinueStatement:
+		return CONTINUE
+
 	case *ast.PrefixExpression:
 		return func(node *ast.PrefixExpression) object.Object {
 			right := Eval(node.Right, env)
 
-			if isError(right) {
+			if isAbrupt(right) {
 				return right
 			}
 
@@ -204,6 +275,8 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 				return evalBangOperator(right)
 			case token.MINUS:
 				return evalNegativeOperator(right)
+			case token.TILDE:
+				return evalBitwiseNotOperator(right)
 			default:
 				return setError("unknown operator: %s%s", node.Operator, right.Type())
 			}
@@ -211,86 +284,75 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 
 	case *ast.FunctionExpression:
 		return &object.Function{
+			Name:       node.Name,
 			Parameters: node.Parameters,
 			Body:       node.Body,
 			Env:        env,
 		}
 
 	case *ast.CallExpression:
+		if member, ok := node.Function.(*ast.MemberExpression); ok {
+			return evalMethodCall(node, member, env)
+		}
+
 		fn := Eval(node.Function, env)
 
-		if isError(fn) {
+		if isAbrupt(fn) {
 			return fn
 		}
 
 		args := evalExpressions(node.FunctionCallParameters, env)
 
-		if len(args) == 1 && isError(args[0]) {
+		if len(args) == 1 && isAbrupt(args[0]) {
 			return args[0]
 		}
 
-		// This enables lexical scoping.
-		//
-		// Why use fnObj.Env instead of the current eval env?
-		// Because the environment where a function is *defined* may differ from the
-		// environment where it is *called*, especially with inner functions (closures).
-		//
-		// Example:
-		//   fn(x) {
-		//       let myFun = fn(y) { x + y };
-		//       myFun(2);
-		//   }
-		//
-		// In this case, `myFun` must resolve `x` from the environment captured when it
-		// was defined, not from the call-site environment.
-		// That captured environment is stored in fnObj.Env.
-		wrappedEnv := object.NewWrappedEnvironment(env)
-
-		switch fnObj := fn.(type) {
-		case *object.Function:
-			for idx, paramId := range fnObj.Parameters {
-				wrappedEnv.Set(paramId.Value, args[idx])
-			}
-
-			bodyEval := Eval(fnObj.Body, wrappedEnv)
-
-			if isError(bodyEval) {
-				return bodyEval
-			}
-
-			if bodyEval.Type() == object.RETURN_OBJ {
-				return bodyEval.(*object.Return).Value
-			}
-
-			return bodyEval
-		case *object.BuiltIn:
-			return fnObj.Fn(args...)
-
-		default:
-			return setError("Object %s(%+v) is not a FUNCTION", fn.Type(), fn)
-		}
+		return applyFunction(fn, args, node.Pos())
 
 	case *ast.ExpressionStatement:
 		return Eval(node.Expression, env)
 
 	case *ast.InfixExpression:
 		left := Eval(node.Left, env)
-		if isError(left) {
+		if isAbrupt(left) {
 			return left
 		}
 
+		// &&, || and ?? short-circuit and evaluate to the operand that decided
+		// the result, not to a boolean: 0 || 2 is 0, null || 2 is 2.
+		switch node.Operator {
+		case token.AND:
+			if !truely(left) {
+				return left
+			}
+			return Eval(node.Right, env)
+		case token.OR:
+			if truely(left) {
+				return left
+			}
+			return Eval(node.Right, env)
+		case token.NULLISH:
+			if left != NULL {
+				return left
+			}
+			return Eval(node.Right, env)
+		}
+
 		right := Eval(node.Right, env)
-		if isError(right) {
+		if isAbrupt(right) {
 			return right
 		}
 
 		return evalInfixExpression(node.Operator, left, right)
 
+	case *ast.AssignExpression:
+		return evalAssignExpression(node, env)
+
 	case *ast.IfExpression:
 		return func(node *ast.IfExpression) object.Object {
 			cond := Eval(node.Conditional, env)
 
-			if isError(cond) {
+			if isAbrupt(cond) {
 				return cond
 			}
 
@@ -306,7 +368,7 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 
 	case *ast.ArrayExpression:
 		elems := evalExpressions(node.Values, env)
-		if len(elems) == 1 && isError(elems[0]) {
+		if len(elems) == 1 && isAbrupt(elems[0]) {
 			return elems[0]
 		}
 
@@ -317,33 +379,43 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 	case *ast.IndexExpression:
 		expr := Eval(node.Left, env)
 
-		if isError(expr) {
+		if isAbrupt(expr) {
 			return expr
 		}
 
+		// Optional access stops at a null left side without evaluating the
+		// index. Only this step is skipped: in a?.b[0] the [0] still applies.
+		if node.Optional && expr == NULL {
+			return NULL
+		}
+
 		index := Eval(node.Index, env)
 
-		if isError(index) {
+		if isAbrupt(index) {
 			return index
 		}
 
 		return evalIndexExpression(expr, index)
 
+	case *ast.SliceExpression:
+		return evalSliceExpression(node, env)
+
+	case *ast.MemberExpression:
+		return evalMemberExpression(node, env)
+
 	case *ast.HashExpression:
-		hash := &object.HashObject{
-			Value: map[object.HashSet]object.HashValue{},
-		}
+		hash := &object.HashObject{}
 
-		for k, v := range node.Pairs {
-			k_obj := Eval(k, env)
+		for _, pair := range node.Pairs {
+			k_obj := Eval(pair.Key, env)
 
-			if isError(k_obj) {
+			if isAbrupt(k_obj) {
 				return k_obj
 			}
 
-			v_obj := Eval(v, env)
+			v_obj := Eval(pair.Value, env)
 
-			if isError(v_obj) {
+			if isAbrupt(v_obj) {
 				return v_obj
 			}
 
@@ -353,14 +425,15 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 				return setError("key is not a Hashable object, got=%s", k_obj.Type())
 			}
 
-			if _, ok := hash.Value[hk_obj.Hash()]; ok {
-				return setError("key %q exists in hash, got=%q: %v", k_obj.Inspect(), k_obj.Inspect(), v_obj.Inspect())
+			if err := checkArrayKey(hk_obj); err != nil {
+				return err
 			}
 
-			hash.Value[hk_obj.Hash()] = object.HashValue{
-				Key:   k_obj,
-				Value: v_obj,
+			if _, ok := hash.Get(hk_obj); ok {
+				return setError("key %q exists in hash, got=%q: %v", k_obj.Inspect(), k_obj.Inspect(), v_obj.Inspect())
 			}
+
+			hash.Set(hk_obj, v_obj)
 		}
 
 		return hash
@@ -369,23 +442,281 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 	return nil
 }
 
+// applyFunction calls fn with args; callPos is recorded in the stack trace of
+// an error raised inside fn.
+func applyFunction(fn object.Object, args []object.Object, callPos token.Position) object.Object {
+	// This enables lexical scoping.
+	//
+	// Why use fnObj.Env instead of the current eval env?
+	// Because the environment where a function is *defined* may differ from the
+	// environment where it is *called*, especially with inner functions (closures).
+	//
+	// Example:
+	//   fn(x) {
+	//       let myFun = fn(y) { x + y };
+	//       myFun(2);
+	//   }
+	//
+	// In this case, `myFun` must resolve `x` from the environment captured when it
+	// was defined, not from the call-site environment.
+	// That captured environment is stored in fnObj.Env.
+	switch fnObj := fn.(type) {
+	case *object.Function:
+		if len(args) != len(fnObj.Parameters) {
+			return setError("function %s expects %s, got %d", fnObj.FrameName(), pluralize(len(fnObj.Parameters), "argument"), len(args))
+		}
+
+		wrappedEnv := object.NewWrappedEnvironment(fnObj.Env)
+
+		for idx, paramId := range fnObj.Parameters {
+			wrappedEnv.Set(paramId.Value, args[idx])
+		}
+
+		bodyEval := Eval(fnObj.Body, wrappedEnv)
+
+		// An empty body, or one ending in a let statement, yields no value.
+		if bodyEval == nil {
+			return NULL
+		}
+
+		if err, ok := bodyEval.(*object.Error); ok {
+			err.Stack = append(err.Stack, object.StackFrame{
+				Function: fnObj.FrameName(),
+				Pos:      callPos,
+			})
+			return err
+		}
+
+		if isError(bodyEval) {
+			return bodyEval
+		}
+
+		if bodyEval.Type() == object.RETURN_OBJ {
+			return bodyEval.(*object.Return).Value
+		}
+
+		return bodyEval
+	case *object.BuiltIn:
+		return fnObj.Fn(args...)
+
+	default:
+		return setError("Object %s(%+v) is not a FUNCTION", fn.Type(), fn)
+	}
+}
+
+// evalWhileStatement runs the body in the enclosing environment, like the
+// branches of an if, so a let in the body rebinds the outer name. A loop is a
+// statement and produces no value, unless a return or an error stops it.
+func evalWhileStatement(node *ast.WhileStatement, env *object.Environment) object.Object {
+	for {
+		cond := Eval(node.Condition, env)
+
+		if isAbrupt(cond) {
+			return cond
+		}
+
+		if !truely(cond) {
+			return nil
+		}
+
+		if obj, done := evalLoopBody(node.Body, env); done {
+			return obj
+		}
+	}
+}
+
+// evalForStatement runs the body once per element, each time in a fresh
+// environment that binds the loop variable. The variable does not outlive the
+// loop, and each closure created in the body keeps its own element.
+func evalForStatement(node *ast.ForStatement, env *object.Environment) object.Object {
+	iterable := Eval(node.Iterable, env)
+
+	if isAbrupt(iterable) {
+		return iterable
+	}
+
+	var elements []object.Object
+
+	switch iterable := iterable.(type) {
+	case *object.Array:
+		elements = iterable.Elements
+	case *object.String:
+		for _, ch := range iterable.Value {
+			elements = append(elements, &object.String{Value: string(ch)})
+		}
+	case *object.HashObject:
+		for _, pair := range iterable.Pairs() {
+			elements = append(elements, pair.Key)
+		}
+	default:
+		return setError("%s is not iterable", iterable.Type())
+	}
+
+	for _, element := range elements {
+		iterationEnv := object.NewWrappedEnvironment(env)
+		iterationEnv.Set(node.Variable.Value, element)
+
+		if obj, done := evalLoopBody(node.Body, iterationEnv); done {
+			return obj
+		}
+	}
+
+	return nil
+}
+
+// evalLoopBody evaluates one iteration and reports whether the loop is done,
+// along with what the loop statement evaluates to in that case.
+func evalLoopBody(body *ast.BlockStatement, env *object.Environment) (object.Object, bool) {
+	obj := Eval(body, env)
+
+	if obj == nil {
+		return nil, false
+	}
+
+	switch obj.Type() {
+	case object.BREAK_OBJ:
+		return nil, true
+	case object.RETURN_OBJ, object.ERROR_OBJ:
+		return obj, true
+	}
+
+	return nil, false
+}
+
+// evalAssignExpression updates the nearest existing binding of the target; it
+// never declares a new one, that is what let is for. A compound operator such
+// as += applies the matching infix operator to the current value first.
+func evalAssignExpression(node *ast.AssignExpression, env *object.Environment) object.Object {
+	switch target := node.Target.(type) {
+	case *ast.IndexExpression:
+		return evalIndexAssignExpression(node, target, env)
+	case *ast.MemberExpression:
+		return evalIndexAssignExpression(node, memberAsIndex(target), env)
+	}
+
+	ident := node.Target.(*ast.Identifier)
+
+	val := Eval(node.Value, env)
+
+	if isAbrupt(val) {
+		return val
+	}
+
+	if node.Operator != token.ASSIGN {
+		curr, ok := env.Get(ident.Value)
+
+		if !ok {
+			return setError("assignment to undeclared identifier: %s", ident.Value)
+		}
+
+		val = evalInfixExpression(strings.TrimSuffix(node.Operator, "="), curr, val)
+
+		if isError(val) {
+			return val
+		}
+	}
+
+	if _, ok := env.Assign(ident.Value, val); !ok {
+		return setError("assignment to undeclared identifier: %s", ident.Value)
+	}
+
+	return val
+}
+
+// evalIndexAssignExpression writes into an array or a hash in place. Arrays and
+// hashes are mutable and shared, so the change is visible through every name
+// bound to the same value; builtins such as push still return a new copy.
+func evalIndexAssignExpression(node *ast.AssignExpression, target *ast.IndexExpression, env *object.Environment) object.Object {
+	container := Eval(target.Left, env)
+
+	if isAbrupt(container) {
+		return container
+	}
+
+	index := Eval(target.Index, env)
+
+	if isAbrupt(index) {
+		return index
+	}
+
+	val := Eval(node.Value, env)
+
+	if isAbrupt(val) {
+		return val
+	}
+
+	if node.Operator != token.ASSIGN {
+		curr := evalIndexExpression(container, index)
+
+		if isError(curr) {
+			return curr
+		}
+
+		val = evalInfixExpression(strings.TrimSuffix(node.Operator, "="), curr, val)
+
+		if isError(val) {
+			return val
+		}
+	}
+
+	switch {
+	case container.Type() == object.ARRAY_OBJ && index.Type() == object.INTEGER_OBJ:
+		arrObj := container.(*object.Array)
+		idx, ok := elementIndex(index.(*object.Integer).Value, len(arrObj.Elements))
+
+		if !ok {
+			return setError("index out of range: %d with length %d", index.(*object.Integer).Value, len(arrObj.Elements))
+		}
+
+		arrObj.Elements[idx] = val
+	case container.Type() == object.HASH:
+		key, ok := index.(object.Hashable)
+
+		if !ok {
+			return setError("key is not a Hashable object, got=%s", index.Type())
+		}
+
+		if err := checkArrayKey(key); err != nil {
+			return err
+		}
+
+		container.(*object.HashObject).Set(key, val)
+	default:
+		return setError("index assignment not supported: %s[%s]", container.Type(), index.Type())
+	}
+
+	return val
+}
+
 func evalIndexExpression(left, right object.Object) object.Object {
 	switch {
 	case left.Type() == object.ARRAY_OBJ && right.Type() == object.INTEGER_OBJ:
 		arrObj := left.(*object.Array)
-		idx := right.(*object.Integer).Value
-		max := int64(len(arrObj.Elements) - 1)
+		idx, ok := elementIndex(right.(*object.Integer).Value, len(arrObj.Elements))
 
-		if idx < 0 || idx > max {
+		if !ok {
 			return NULL
 		}
 
 		return arrObj.Elements[idx]
+	case left.Type() == object.STRING_OBJ && right.Type() == object.INTEGER_OBJ:
+		// Strings are indexed by code point, like len and slices count them.
+		runes := []rune(left.(*object.String).Value)
+		idx, ok := elementIndex(right.(*object.Integer).Value, len(runes))
+
+		if !ok {
+			return NULL
+		}
+
+		return &object.String{Value: string(runes[idx])}
 	case left.Type() == object.HASH:
 		hash, ok := right.(object.Hashable)
 		if !ok {
 			return setError("index hash not supported, got=%s", right.Type())
 		}
+		if err := checkArrayKey(hash); err != nil {
+			return err
+		}
 		hashValue := left.(*object.HashObject)
 		return evalHashIndexExpression(hashValue, hash)
 
@@ -394,14 +725,146 @@ func evalIndexExpression(left, right object.Object) object.Object {
 	}
 }
 
+// elementIndex resolves an index into a sequence of length n. A negative index
+// counts from the end, so -1 is the last element. It reports false when the
+// index is out of range either way.
+func elementIndex(idx int64, n int) (int, bool) {
+	if idx < 0 {
+		idx += int64(n)
+	}
+
+	if idx < 0 || idx >= int64(n) {
+		return 0, false
+	}
+
+	return int(idx), true
+}
+
+// evalSliceExpression copies the elements of an array, or the code points of a
+// string, from low up to, not including, high. As in Python, a missing low is
+// the start and a missing high the end, negative bounds count from the end and
+// out of range bounds are clamped, so a slice is empty rather than an error
+// when the bounds do not overlap the value.
+func evalSliceExpression(node *ast.SliceExpression, env *object.Environment) object.Object {
+	left := Eval(node.Left, env)
+
+	if isAbrupt(left) {
+		return left
+	}
+
+	if node.Optional && left == NULL {
+		return NULL
+	}
+
+	var length int
+
+	switch left := left.(type) {
+	case *object.Array:
+		length = len(left.Elements)
+	case *object.String:
+		length = utf8.RuneCountInString(left.Value)
+	default:
+		return setError("slice operation not supported, got=%s", left.Type())
+	}
+
+	low, err := evalSliceBound(node.Low, 0, length, env)
+
+	if err != nil {
+		return err
+	}
+
+	high, err := evalSliceBound(node.High, length, length, env)
+
+	if err != nil {
+		return err
+	}
+
+	if high < low {
+		high = low
+	}
+
+	switch left := left.(type) {
+	case *object.Array:
+		elements := make([]object.Object, high-low)
+		copy(elements, left.Elements[low:high])
+		return &object.Array{Elements: elements}
+	default:
+		runes := []rune(left.(*object.String).Value)
+		return &object.String{Value: string(runes[low:high])}
+	}
+}
+
+// evalSliceBound evaluates a slice bound and clamps it to [0, length]. A nil
+// bound was omitted and is def.
+func evalSliceBound(bound ast.Expression, def, length int, env *object.Environment) (int, object.Object) {
+	if bound == nil {
+		return def, nil
+	}
+
+	obj := Eval(bound, env)
+
+	if isAbrupt(obj) {
+		return 0, obj
+	}
+
+	var idx int64
+
+	switch obj := obj.(type) {
+	case *object.Integer:
+		idx = obj.Value
+	case *object.BigInt:
+		// Far out of range either way, it clamps to one of the ends.
+		if obj.Value.Sign() > 0 {
+			return length, nil
+		}
+		return 0, nil
+	default:
+		return 0, setError("slice index must be an integer, got=%s", obj.Type())
+	}
+
+	if idx < 0 {
+		idx += int64(length)
+	}
+
+	switch {
+	case idx < 0:
+		return 0, nil
+	case idx > int64(length):
+		return length, nil
+	default:
+		return int(idx), nil
+	}
+}
+
+// checkArrayKey rejects an array key holding an element that is not Hashable,
+// or holding itself. Any other Hashable is a valid key.
+func checkArrayKey(key object.Hashable) *object.Error {
+	arr, ok := key.(*object.Array)
+
+	if !ok {
+		return nil
+	}
+
+	if bad, path := arr.UnhashableElement(); bad != nil {
+		// Arrays are Hashable, so an array found here is one that holds itself.
+		if bad.Type() == object.ARRAY_OBJ {
+			return setError("key is not a Hashable object, got=ARRAY_OBJ holding itself at %s", path)
+		}
+
+		return setError("key is not a Hashable object, got=ARRAY_OBJ with %s at %s", bad.Type(), path)
+	}
+
+	return nil
+}
+
 func evalHashIndexExpression(left *object.HashObject, index object.Hashable) object.Object {
-	valueObj, ok := left.Value[index.Hash()]
+	value, ok := left.Get(index)
 
 	if !ok {
 		return NULL
 	}
 
-	return valueObj.Value
+	return value
 }
 
 func truely(cond object.Object) bool {
@@ -422,7 +885,7 @@ func evalExpressions(params []ast.Expression, env *object.Environment) []object.
 
 	for _, param := range params {
 		evaluated := Eval(param, env)
-		if isError(evaluated) {
+		if isAbrupt(evaluated) {
 			return []object.Object{evaluated}
 		}
 
@@ -434,47 +897,16 @@ func evalExpressions(params []ast.Expression, env *object.Environment) []object.
 
 func evalInfixExpression(operator string, left, right object.Object) object.Object {
 	switch {
-	case left.Type() == object.INTEGER_OBJ && right.Type() == object.INTEGER_OBJ:
-		return func(operator string, left, right object.Object) object.Object {
-			leftInt := left.(*object.Integer).Value
-			rightInt := right.(*object.Integer).Value
-
-			switch operator {
-			case token.PLUS:
-				return &object.Integer{Value: leftInt + rightInt}
-			case token.MINUS:
-				return &object.Integer{Value: leftInt - rightInt}
-			case token.ASTERISK:
-				return &object.Integer{Value: leftInt * rightInt}
-			case token.SLASH:
-				return &object.Integer{Value: leftInt / rightInt}
-			case token.EQ:
-				return nativeBoolToBooleanObj(leftInt == rightInt)
-			case token.NOT_EQ:
-				return nativeBoolToBooleanObj(leftInt != rightInt)
-			case token.LT_EQ:
-				return nativeBoolToBooleanObj(leftInt <= rightInt)
-			case token.GT_EQ:
-				return nativeBoolToBooleanObj(leftInt >= rightInt)
-			case token.LT:
-				return nativeBoolToBooleanObj(leftInt < rightInt)
-			case token.GT:
-				return nativeBoolToBooleanObj(leftInt > rightInt)
-			default:
-				return setError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
-			}
-		}(operator, left, right)
+	case isInteger(left) && isInteger(right):
+		return evalIntegerInfixExpression(operator, left, right)
+	case isNumeric(left) && isNumeric(right):
+		return evalFloatInfixExpression(operator, left, right)
 	case left.Type() == object.STRING_OBJ && right.Type() == object.STRING_OBJ:
-		if operator != token.PLUS {
-			return setError("type mismatch: %s %s %s", left.Type(), operator, right.Type())
-		}
-		return &object.String{
-			Value: left.Inspect() + right.Inspect(),
-		}
+		return evalStringInfixExpression(operator, left, right)
 	case operator == token.EQ:
-		return nativeBoolToBooleanObj(left == right)
+		return nativeBoolToBooleanObj(object.Equal(left, right))
 	case operator == token.NOT_EQ:
-		return nativeBoolToBooleanObj(left != right)
+		return nativeBoolToBooleanObj(!object.Equal(left, right))
 	case left.Type() != right.Type():
 		return setError("type mismatch: %s %s %s", left.Type(), operator, right.Type())
 	default:
@@ -482,6 +914,373 @@ func evalInfixExpression(operator string, left, right object.Object) object.Obje
 	}
 }
 
+// evalIntegerInfixExpression handles integer operands. Arithmetic is exact:
+// a result that overflows int64 is promoted to a BigInt, and a result that
+// fits in int64 is always an Integer, whatever its operands were.
+func evalIntegerInfixExpression(operator string, left, right object.Object) object.Object {
+	leftInt, leftOk := left.(*object.Integer)
+	rightInt, rightOk := right.(*object.Integer)
+
+	if leftOk && rightOk {
+		if result := evalInt64InfixExpression(operator, leftInt.Value, rightInt.Value); result != nil {
+			return result
+		}
+	}
+
+	return evalBigIntInfixExpression(operator, left, right)
+}
+
+// evalInt64InfixExpression applies operator to two Integers, or returns nil
+// when the result overflows int64 and must be computed as a BigInt.
+func evalInt64InfixExpression(operator string, leftInt, rightInt int64) object.Object {
+	switch operator {
+	case token.PLUS:
+		sum := leftInt + rightInt
+		if (sum > leftInt) != (rightInt > 0) {
+			return nil
+		}
+		return &object.Integer{Value: sum}
+	case token.MINUS:
+		diff := leftInt - rightInt
+		if (diff < leftInt) != (rightInt > 0) {
+			return nil
+		}
+		return &object.Integer{Value: diff}
+	case token.ASTERISK:
+		if !productFits(leftInt, rightInt) {
+			return nil
+		}
+		return &object.Integer{Value: leftInt * rightInt}
+	case token.SLASH:
+		if rightInt == 0 {
+			return setError("division by zero: %d / 0", leftInt)
+		}
+		if leftInt == math.MinInt64 && rightInt == -1 {
+			return nil
+		}
+		return &object.Integer{Value: leftInt / rightInt}
+	case token.PERCENT:
+		if rightInt == 0 {
+			return setError("modulo by zero: %d %% 0", leftInt)
+		}
+		return &object.Integer{Value: leftInt % rightInt}
+	case token.POWER:
+		if rightInt < 0 {
+			return &object.Float{Value: math.Pow(float64(leftInt), float64(rightInt))}
+		}
+		power, ok := intPow(leftInt, rightInt)
+		if !ok {
+			return nil
+		}
+		return &object.Integer{Value: power}
+	case token.AMPERSAND:
+		return &object.Integer{Value: leftInt & rightInt}
+	case token.PIPE:
+		return &object.Integer{Value: leftInt | rightInt}
+	case token.CARET:
+		return &object.Integer{Value: leftInt ^ rightInt}
+	case token.SHL, token.SHR:
+		if rightInt < 0 {
+			return setError("negative shift count: %d", rightInt)
+		}
+		if operator == token.SHR {
+			return &object.Integer{Value: leftInt >> rightInt}
+		}
+		if leftInt != 0 && (rightInt >= 63 || (leftInt<<rightInt)>>rightInt != leftInt) {
+			return nil
+		}
+		return &object.Integer{Value: leftInt << rightInt}
+	case token.EQ:
+		return nativeBoolToBooleanObj(leftInt == rightInt)
+	case token.NOT_EQ:
+		return nativeBoolToBooleanObj(leftInt != rightInt)
+	case token.LT_EQ:
+		return nativeBoolToBooleanObj(leftInt <= rightInt)
+	case token.GT_EQ:
+		return nativeBoolToBooleanObj(leftInt >= rightInt)
+	case token.LT:
+		return nativeBoolToBooleanObj(leftInt < rightInt)
+	case token.GT:
+		return nativeBoolToBooleanObj(leftInt > rightInt)
+	default:
+		return setError("unknown operator: %s %s %s", object.INTEGER_OBJ, operator, object.INTEGER_OBJ)
+	}
+}
+
+// evalBigIntInfixExpression applies operator to integers of any size. Like
+// int64 arithmetic, division and modulo truncate toward zero.
+func evalBigIntInfixExpression(operator string, left, right object.Object) object.Object {
+	leftInt := toBigInt(left)
+	rightInt := toBigInt(right)
+	result := new(big.Int)
+
+	switch operator {
+	case token.PLUS:
+		result.Add(leftInt, rightInt)
+	case token.MINUS:
+		result.Sub(leftInt, rightInt)
+	case token.ASTERISK:
+		result.Mul(leftInt, rightInt)
+	case token.SLASH:
+		if rightInt.Sign() == 0 {
+			return setError("division by zero: %d / 0", leftInt)
+		}
+		result.Quo(leftInt, rightInt)
+	case token.PERCENT:
+		if rightInt.Sign() == 0 {
+			return setError("modulo by zero: %d %% 0", leftInt)
+		}
+		result.Rem(leftInt, rightInt)
+	case token.POWER:
+		if rightInt.Sign() < 0 {
+			return &object.Float{Value: math.Pow(toFloat(left), toFloat(right))}
+		}
+		if !rightInt.IsInt64() {
+			return setError("exponent too large: %d", rightInt)
+		}
+		if leftInt.CmpAbs(big.NewInt(1)) > 0 && rightInt.Int64() > maxIntegerBits/int64(leftInt.BitLen()) {
+			return setError("integer too large: %d ** %d exceeds %d bits", leftInt, rightInt, maxIntegerBits)
+		}
+		result.Exp(leftInt, rightInt, nil)
+	case token.AMPERSAND:
+		result.And(leftInt, rightInt)
+	case token.PIPE:
+		result.Or(leftInt, rightInt)
+	case token.CARET:
+		result.Xor(leftInt, rightInt)
+	case token.SHL, token.SHR:
+		if rightInt.Sign() < 0 {
+			return setError("negative shift count: %d", rightInt)
+		}
+		if !rightInt.IsInt64() {
+			return setError("shift count too large: %d", rightInt)
+		}
+		if operator == token.SHL && leftInt.Sign() != 0 && rightInt.Int64() > int64(maxIntegerBits-leftInt.BitLen()) {
+			return setError("integer too large: %d << %d exceeds %d bits", leftInt, rightInt, maxIntegerBits)
+		}
+		if operator == token.SHL {
+			result.Lsh(leftInt, uint(rightInt.Int64()))
+		} else {
+			result.Rsh(leftInt, uint(rightInt.Int64()))
+		}
+	case token.EQ:
+		return nativeBoolToBooleanObj(leftInt.Cmp(rightInt) == 0)
+	case token.NOT_EQ:
+		return nativeBoolToBooleanObj(leftInt.Cmp(rightInt) != 0)
+	case token.LT_EQ:
+		return nativeBoolToBooleanObj(leftInt.Cmp(rightInt) <= 0)
+	case token.GT_EQ:
+		return nativeBoolToBooleanObj(leftInt.Cmp(rightInt) >= 0)
+	case token.LT:
+		return nativeBoolToBooleanObj(leftInt.Cmp(rightInt) < 0)
+	case token.GT:
+		return nativeBoolToBooleanObj(leftInt.Cmp(rightInt) > 0)
+	default:
+		return setError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+
+	return newInteger(result)
+}
+
+// evalFloatInfixExpression handles arithmetic and comparisons where at least
+// one operand is a float. The other operand is promoted to a float, so the
+// result of arithmetic is always a float; division follows IEEE 754 and
+// dividing by zero yields +Inf, -Inf or NaN. Bitwise operators are integer
+// only.
+//
+// A BigInt is compared with a float exactly rather than rounded to one, so that
+// comparisons agree with object.Equal and with hash keys.
+func evalFloatInfixExpression(operator string, left, right object.Object) object.Object {
+	if left.Type() == object.BIGINT_OBJ || right.Type() == object.BIGINT_OBJ {
+		switch operator {
+		case token.EQ:
+			return nativeBoolToBooleanObj(object.Equal(left, right))
+		case token.NOT_EQ:
+			return nativeBoolToBooleanObj(!object.Equal(left, right))
+		case token.LT_EQ, token.GT_EQ, token.LT, token.GT:
+			return evalBigFloatComparison(operator, left, right)
+		}
+	}
+
+	leftFloat := toFloat(left)
+	rightFloat := toFloat(right)
+
+	switch operator {
+	case token.PLUS:
+		return &object.Float{Value: leftFloat + rightFloat}
+	case token.MINUS:
+		return &object.Float{Value: leftFloat - rightFloat}
+	case token.ASTERISK:
+		return &object.Float{Value: leftFloat * rightFloat}
+	case token.SLASH:
+		return &object.Float{Value: leftFloat / rightFloat}
+	case token.PERCENT:
+		return &object.Float{Value: math.Mod(leftFloat, rightFloat)}
+	case token.POWER:
+		return &object.Float{Value: math.Pow(leftFloat, rightFloat)}
+	case token.EQ:
+		return nativeBoolToBooleanObj(leftFloat == rightFloat)
+	case token.NOT_EQ:
+		return nativeBoolToBooleanObj(leftFloat != rightFloat)
+	case token.LT_EQ:
+		return nativeBoolToBooleanObj(leftFloat <= rightFloat)
+	case token.GT_EQ:
+		return nativeBoolToBooleanObj(leftFloat >= rightFloat)
+	case token.LT:
+		return nativeBoolToBooleanObj(leftFloat < rightFloat)
+	case token.GT:
+		return nativeBoolToBooleanObj(leftFloat > rightFloat)
+	default:
+		return setError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+}
+
+// evalBigFloatComparison orders a BigInt and a float without rounding either.
+// Like any comparison with NaN, it is false when the float is NaN.
+func evalBigFloatComparison(operator string, left, right object.Object) object.Object {
+	leftFloat, leftOk := toBigFloat(left)
+	rightFloat, rightOk := toBigFloat(right)
+
+	if !leftOk || !rightOk {
+		return FALSE
+	}
+
+	cmp := leftFloat.Cmp(rightFloat)
+
+	switch operator {
+	case token.LT_EQ:
+		return nativeBoolToBooleanObj(cmp <= 0)
+	case token.GT_EQ:
+		return nativeBoolToBooleanObj(cmp >= 0)
+	case token.LT:
+		return nativeBoolToBooleanObj(cmp < 0)
+	default:
+		return nativeBoolToBooleanObj(cmp > 0)
+	}
+}
+
+// evalStringInfixExpression concatenates strings with + and orders them
+// byte-wise, which for UTF-8 is the order of their code points.
+func evalStringInfixExpression(operator string, left, right object.Object) object.Object {
+	leftStr := left.(*object.String).Value
+	rightStr := right.(*object.String).Value
+
+	switch operator {
+	case token.PLUS:
+		return &object.String{Value: leftStr + rightStr}
+	case token.EQ:
+		return nativeBoolToBooleanObj(leftStr == rightStr)
+	case token.NOT_EQ:
+		return nativeBoolToBooleanObj(leftStr != rightStr)
+	case token.LT_EQ:
+		return nativeBoolToBooleanObj(leftStr <= rightStr)
+	case token.GT_EQ:
+		return nativeBoolToBooleanObj(leftStr >= rightStr)
+	case token.LT:
+		return nativeBoolToBooleanObj(leftStr < rightStr)
+	case token.GT:
+		return nativeBoolToBooleanObj(leftStr > rightStr)
+	default:
+		return setError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+}
+
+// intPow raises base to a non-negative exp by repeated squaring. It reports
+// false when the result overflows int64.
+func intPow(base, exp int64) (int64, bool) {
+	result := int64(1)
+
+	for exp > 0 {
+		if exp&1 == 1 {
+			if !productFits(result, base) {
+				return 0, false
+			}
+			result *= base
+		}
+
+		exp >>= 1
+
+		if exp > 0 {
+			if !productFits(base, base) {
+				return 0, false
+			}
+			base *= base
+		}
+	}
+
+	return result, true
+}
+
+// productFits reports whether a * b fits in int64.
+func productFits(a, b int64) bool {
+	if a == 0 || b == 0 {
+		return true
+	}
+
+	if a == -1 || b == -1 {
+		return a != math.MinInt64 && b != math.MinInt64
+	}
+
+	product := a * b
+	return product/b == a
+}
+
+func isInteger(obj object.Object) bool {
+	return obj.Type() == object.INTEGER_OBJ || obj.Type() == object.BIGINT_OBJ
+}
+
+func isNumeric(obj object.Object) bool {
+	return isInteger(obj) || obj.Type() == object.FLOAT_OBJ
+}
+
+func toFloat(obj object.Object) float64 {
+	switch obj := obj.(type) {
+	case *object.Integer:
+		return float64(obj.Value)
+	case *object.BigInt:
+		f, _ := new(big.Float).SetInt(obj.Value).Float64()
+		return f
+	case *object.Float:
+		return obj.Value
+	default:
+		return 0
+	}
+}
+
+func toBigInt(obj object.Object) *big.Int {
+	switch obj := obj.(type) {
+	case *object.Integer:
+		return big.NewInt(obj.Value)
+	case *object.BigInt:
+		return obj.Value
+	default:
+		return new(big.Int)
+	}
+}
+
+// toBigFloat converts a number to a big.Float exactly. It reports false for
+// NaN, which big.Float cannot represent.
+func toBigFloat(obj object.Object) (*big.Float, bool) {
+	if f, ok := obj.(*object.Float); ok {
+		if math.IsNaN(f.Value) {
+			return nil, false
+		}
+		return big.NewFloat(f.Value), true
+	}
+
+	return new(big.Float).SetInt(toBigInt(obj)), true
+}
+
+// newInteger returns value as an Integer if it fits in int64, as a BigInt
+// otherwise.
+func newInteger(value *big.Int) object.Object {
+	if value.IsInt64() {
+		return &object.Integer{Value: value.Int64()}
+	}
+
+	return &object.BigInt{Value: value}
+}
+
 func nativeBoolToBooleanObj(evaluated bool) *object.Boolean {
 	if evaluated {
 		return TRUE
@@ -504,19 +1303,60 @@ func evalBangOperator(toEval object.Object) object.Object {
 }
 
 func evalNegativeOperator(toEval object.Object) object.Object {
-	if toEval.Type() != object.INTEGER_OBJ {
+	switch obj := toEval.(type) {
+	case *object.Integer:
+		if obj.Value == math.MinInt64 {
+			return newInteger(new(big.Int).Neg(big.NewInt(obj.Value)))
+		}
+		return &object.Integer{Value: -obj.Value}
+	case *object.BigInt:
+		return newInteger(new(big.Int).Neg(obj.Value))
+	case *object.Float:
+		return &object.Float{Value: -obj.Value}
+	default:
 		return setError("unknown operator: -%s", toEval.Type())
 	}
+}
 
-	value := toEval.(*object.Integer).Value
+func evalBitwiseNotOperator(toEval object.Object) object.Object {
+	switch obj := toEval.(type) {
+	case *object.Integer:
+		return &object.Integer{Value: ^obj.Value}
+	case *object.BigInt:
+		return newInteger(new(big.Int).Not(obj.Value))
+	default:
+		return setError("unknown operator: ~%s", toEval.Type())
+	}
+}
+
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
 
-	return &object.Integer{Value: -value}
+	return fmt.Sprintf("%d %ss", n, noun)
 }
 
 func setError(format string, err ...any) *object.Error {
 	return &object.Error{Message: fmt.Sprintf(format, err...)}
 }
 
+// isAbrupt reports whether obj cuts short the evaluation of the node whose
+// operand it is: an error, or a return, break or continue on its way to the
+// function or loop that handles it. Like isError, it is true for nil.
+func isAbrupt(obj object.Object) bool {
+	if obj == nil {
+		return true
+	}
+
+	switch obj.Type() {
+	case object.ERROR_OBJ, object.RETURN_OBJ, object.BREAK_OBJ, object.CONTINUE_OBJ:
+		return true
+	}
+
+	return false
+}
+
 func isError(obj object.Object) bool {
 	if obj != nil {
 		return obj.Type() == object.ERROR_OBJ
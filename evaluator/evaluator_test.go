@@ -1,6 +1,7 @@
 package evaluator
 
 import (
+	"math"
 	"strconv"
 	"testing"
 
@@ -25,6 +26,26 @@ func TestIntegerEvaluation(t *testing.T) {
 		{"2 * 2 * 2 * 2", 16},
 		{"2 * (2 + 3) / 1", 10},
 		{"10 + 10 + (20 * 5 + (10 -2))", 128},
+		{"7 % 3", 1},
+		{"-7 % 3", -1},
+		{"2 ** 10", 1024},
+		{"2 ** 3 ** 2", 512},
+		{"-2 ** 2", -4},
+		{"(-2) ** 3", -8},
+		{"5 ** 0", 1},
+		{"6 & 3", 2},
+		{"6 | 3", 7},
+		{"6 ^ 3", 5},
+		{"~5", -6},
+		{"1 << 4", 16},
+		{"-16 >> 2", -4},
+		{"1 << 62", 4611686018427387904},
+		{"1 | 2 + 4", 7},
+		{"1 + 2 << 1", 6},
+		{"2 * 3 % 4", 2},
+		{"0xff + 0o10 + 0b11", 266},
+		{"1_000 * 1_000", 1000000},
+		{"0xF0 & 0b1111_0000 >> 4", 0},
 	}
 
 	for _, tt := range test {
@@ -34,6 +55,140 @@ func TestIntegerEvaluation(t *testing.T) {
 
 }
 
+func TestFloatEvaluation(t *testing.T) {
+	test := []struct {
+		input    string
+		expected float64
+	}{
+		{"3.14", 3.14},
+		{"1.5e-3", 0.0015},
+		{"-2.5", -2.5},
+		{"0.5 + 0.25", 0.75},
+		{"1 + 2.5", 3.5},
+		{"2.5 + 1", 3.5},
+		{"10 - 0.5", 9.5},
+		{"1.5 * 2", 3},
+		{"7 / 2.0", 3.5},
+		{"7.0 / 2", 3.5},
+		{"1e3 * 1e-3", 1},
+		{"-(1.5 + 1)", -2.5},
+		{"1.0 / 0", math.Inf(1)},
+		{"-1.0 / 0", math.Inf(-1)},
+		{"7.5 % 2", 1.5},
+		{"2.0 ** 3", 8},
+		{"4 ** 0.5", 2},
+		{"2 ** -1", 0.5},
+	}
+
+	for _, tt := range test {
+		testFloatObject(t, evalExpr(tt.input), tt.expected)
+	}
+
+	// Integer division stays integral.
+	testIntegerObject(t, evalExpr("7 / 2"), 3)
+
+	nan, ok := evalExpr("0.0 / 0").(*object.Float)
+
+	if !ok || !math.IsNaN(nan.Value) {
+		t.Errorf("0.0 / 0 is not NaN, got=%+v", nan)
+	}
+}
+
+func TestFloatInspect(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"3.0", "3.0"},
+		{"1.5", "1.5"},
+		{"2 * 0.5", "1.0"},
+		{"1e21", "1e+21"},
+		{"1.0 / 0", "+Inf"},
+	}
+
+	for _, tt := range tests {
+		evaluated := evalExpr(tt.input)
+
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("wrong Inspect() for %q, expected=%q, got=%q", tt.input, tt.expected, evaluated.Inspect())
+		}
+	}
+}
+
+func TestHashDuplicateNumericKeys(t *testing.T) {
+	evaluated := evalExpr(`{1: 1, 1.0: 2}`)
+
+	if _, ok := evaluated.(*object.Error); !ok {
+		t.Errorf("obj is not *objectError, got=%T(%+v)", evaluated, evaluated)
+	}
+}
+
+func TestBigIntEvaluation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		typ      object.ObjectType
+	}{
+		{"9223372036854775807 + 1", "9223372036854775808", object.BIGINT_OBJ},
+		{"-9223372036854775807 - 2", "-9223372036854775809", object.BIGINT_OBJ},
+		{"4294967296 * 4294967296", "18446744073709551616", object.BIGINT_OBJ},
+		{"2 ** 100", "1267650600228229401496703205376", object.BIGINT_OBJ},
+		{"(-2) ** 63", "-9223372036854775808", object.INTEGER_OBJ},
+		{"3 ** 40", "12157665459056928801", object.BIGINT_OBJ},
+		{"1 << 64", "18446744073709551616", object.BIGINT_OBJ},
+		{"-1 << 63", "-9223372036854775808", object.INTEGER_OBJ},
+		{"(1 << 100) >> 98", "4", object.INTEGER_OBJ},
+		{"(2 ** 64) >> 100000000000", "0", object.INTEGER_OBJ},
+		{"0 << 100000000000", "0", object.INTEGER_OBJ},
+		{"(-1) ** 100000000001", "-1", object.INTEGER_OBJ},
+		{"-(-9223372036854775807 - 1)", "9223372036854775808", object.BIGINT_OBJ},
+		{"(-9223372036854775807 - 1) / -1", "9223372036854775808", object.BIGINT_OBJ},
+		{"(2 ** 64 + 1) - 2 ** 64", "1", object.INTEGER_OBJ},
+		{"2 ** 64 / 2 ** 32", "4294967296", object.INTEGER_OBJ},
+		{"-(2 ** 70) / 3", "-393530540239137101141", object.BIGINT_OBJ},
+		{"-(2 ** 70) % 3", "-1", object.INTEGER_OBJ},
+		{"~(2 ** 64)", "-18446744073709551617", object.BIGINT_OBJ},
+		{"(2 ** 64 + 5) & 7", "5", object.INTEGER_OBJ},
+		{"2 ** 64 | 1", "18446744073709551617", object.BIGINT_OBJ},
+		{"2 ** 64 ^ 2 ** 64", "0", object.INTEGER_OBJ},
+		{"2 ** 64 * 0.5", "9.223372036854776e+18", object.FLOAT_OBJ},
+		{"(2 ** 64) ** -1", "5.421010862427522e-20", object.FLOAT_OBJ},
+		{"2 ** 64 > 2 ** 63", "true", object.BOOLEAN_OBJ},
+		{"2 ** 64 == 2 ** 64", "true", object.BOOLEAN_OBJ},
+		{"2 ** 64 == 9223372036854775807 * 2 + 2", "true", object.BOOLEAN_OBJ},
+		{"2 ** 64 == 2.0 ** 64", "true", object.BOOLEAN_OBJ},
+		{"2 ** 64 + 1 == 2.0 ** 64", "false", object.BOOLEAN_OBJ},
+		{"2 ** 64 != 1", "true", object.BOOLEAN_OBJ},
+		{"2 ** 64 + 1 > 2.0 ** 64", "true", object.BOOLEAN_OBJ},
+		{"2 ** 64 >= 2.0 ** 64", "true", object.BOOLEAN_OBJ},
+		{"1.5 < 2 ** 64", "true", object.BOOLEAN_OBJ},
+		{"2 ** 64 < 1.0 / 0", "true", object.BOOLEAN_OBJ},
+		{"2 ** 64 < 0.0 / 0", "false", object.BOOLEAN_OBJ},
+		{"2 ** 64 > 0.0 / 0", "false", object.BOOLEAN_OBJ},
+		{"-(2 ** 64) < 0", "true", object.BOOLEAN_OBJ},
+		{`let h = {2 ** 64: "big"}; h[2.0 ** 64] + h[9223372036854775807 * 2 + 2]`, "bigbig", object.STRING_OBJ},
+		{`{2 ** 64 + 1: 1}[2 ** 64 + 1]`, "1", object.INTEGER_OBJ},
+		{"let fact = fn(n) { if (n < 2) { 1 } else { n * fact(n - 1) } }; fact(25)", "15511210043330985984000000", object.BIGINT_OBJ},
+		{"let fact = fn(n) { if (n < 2) { 1 } else { n * fact(n - 1) } }; fact(25) / fact(23)", "600", object.INTEGER_OBJ},
+		{`"${2 ** 64}"`, "18446744073709551616", object.STRING_OBJ},
+		{"18446744073709551616", "18446744073709551616", object.BIGINT_OBJ},
+		{"0xFFFF_FFFF_FFFF_FFFF", "18446744073709551615", object.BIGINT_OBJ},
+		{"18446744073709551616 == 2 ** 64", "true", object.BOOLEAN_OBJ},
+		{"-9223372036854775808", "-9223372036854775808", object.INTEGER_OBJ},
+		{"9223372036854775808 - 1", "9223372036854775807", object.INTEGER_OBJ},
+		{"100000000000000000000 % 97", "73", object.INTEGER_OBJ},
+		{`{2 ** 64: "big"}[18446744073709551616]`, "big", object.STRING_OBJ},
+	}
+
+	for _, tt := range tests {
+		evaluated := evalExpr(tt.input)
+
+		if evaluated.Type() != tt.typ || evaluated.Inspect() != tt.expected {
+			t.Errorf("%s: expected %s(%s), got=%s(%s)", tt.input, tt.typ, tt.expected, evaluated.Type(), evaluated.Inspect())
+		}
+	}
+}
+
 func TestBooleanEvaluation(t *testing.T) {
 	test := []struct {
 		input    string
@@ -58,6 +213,41 @@ func TestBooleanEvaluation(t *testing.T) {
 		{"2 <= 1", false},
 		{"(1 < 2) == false", false},
 		{"(1 != 1) == false", true},
+		{"1 == 1.0", true},
+		{"1.0 == 1", true},
+		{"1.5 != 1", true},
+		{"0.5 < 1", true},
+		{"2 > 1.5", true},
+		{"1.5 >= 1.5", true},
+		{"2 <= 1.5", false},
+		{"0.1 + 0.2 == 0.3", false},
+		{"0.0 / 0 == 0.0 / 0", false},
+		{`"a" == "a"`, true},
+		{`"a" != "a"`, false},
+		{`"a" == "b"`, false},
+		{`"a" < "b"`, true},
+		{`"b" <= "a"`, false},
+		{`"abc" > "abd"`, false},
+		{`"ab" < "abc"`, true},
+		{`"B" < "a"`, true},
+		{`"b" >= "b"`, true},
+		{`"1" == 1`, false},
+		{`"1" != 1`, true},
+		{"[1, 2] == [1, 2]", true},
+		{"[1, 2] == [2, 1]", false},
+		{"[1, [2, 3]] == [1, [2, 3]]", true},
+		{"[1, 2] != [1, 2, 3]", true},
+		{"[1] == [1.0]", true},
+		{"[] == []", true},
+		{`{"a": 1, "b": [2]} == {"b": [2], "a": 1}`, true},
+		{`{"a": 1} == {"a": 2}`, false},
+		{`{"a": 1} != {"a": 1, "b": 2}`, true},
+		{"null == null", true},
+		{"[null] == [null]", true},
+		{"let f = fn() { 1 }; f == f", true},
+		{"fn() { 1 } == fn() { 1 }", false},
+		{"len == len", true},
+		{"[1] == 1", false},
 	}
 
 	for _, tt := range test {
@@ -152,6 +342,32 @@ func TestErrorEvaluation(t *testing.T) {
 		{"foobar", "identifier not found: foobar"},
 		{`{"test":2}[fn(x){x}]`, "index hash not supported, got=FUNCTION"},
 		{`{fn(x){x}:2}`, "key is not a Hashable object, got=FUNCTION"},
+		{"1.5 + true", "type mismatch: FLOAT + BOOLEAN"},
+		{"-true + 1.5", "unknown operator: -BOOLEAN"},
+		{`"a" - "b"`, "unknown operator: STRING_OBJ - STRING_OBJ"},
+		{`"a" < 1`, "type mismatch: STRING_OBJ < INTEGER"},
+		{"5 / 0", "division by zero: 5 / 0"},
+		{"5 % 0", "modulo by zero: 5 % 0"},
+		{"let x = 0; 1 / x", "division by zero: 1 / 0"},
+		{"1 << -1", "negative shift count: -1"},
+		{"2 ** 64 / 0", "division by zero: 18446744073709551616 / 0"},
+		{"2 ** 64 % 0", "modulo by zero: 18446744073709551616 % 0"},
+		{"2 ** 64 >> -(2 ** 64)", "negative shift count: -18446744073709551616"},
+		{"1 << 2 ** 64", "shift count too large: 18446744073709551616"},
+		{"2 ** 2 ** 64", "exponent too large: 18446744073709551616"},
+		{"1 << 100000000000", "integer too large: 1 << 100000000000 exceeds 1048576 bits"},
+		{"2 ** 4000000000", "integer too large: 2 ** 4000000000 exceeds 1048576 bits"},
+		{"(-3) ** 1000000", "integer too large: -3 ** 1000000 exceeds 1048576 bits"},
+		{"2 ** 64 + true", "type mismatch: BIGINT + BOOLEAN"},
+		{"~1.5", "unknown operator: ~FLOAT"},
+		{"~true", "unknown operator: ~BOOLEAN"},
+		{"1.5 & 1", "unknown operator: FLOAT & INTEGER"},
+		{"true | false", "unknown operator: BOOLEAN | BOOLEAN"},
+		{"len(1.5)", "argument to 'len' is not supported, got=FLOAT"},
+		{"first(1.5)", "argument to 'first' is not supported, got=FLOAT"},
+		{"[1, 2][1.0]", "index operation not supported, got=ARRAY_OBJ"},
+		{`"ab"["a"]`, "index operation not supported, got=STRING_OBJ"},
+		{"rest(1)", "argument to 'rest' is not supported, got=INTEGER"},
 	}
 
 	for _, tt := range test {
@@ -170,6 +386,110 @@ func TestErrorEvaluation(t *testing.T) {
 	}
 }
 
+func TestErrorPositions(t *testing.T) {
+	test := []struct {
+		input string
+		pos   string
+	}{
+		{"5 + true;", "1:1"},
+		{"let x = 1;\nlet y = x + true;", "2:9"},
+		{"let x = 1;\n\n  foobar;", "3:3"},
+		{"if (true) {\n  -true\n}", "2:3"},
+		{"let f = fn(x) {\n  x + true;\n};\nf(1);", "2:3"},
+		{"let x = 1;\nlen(1, 2)", "2:1"},
+		{`let saudação = "olá" + true;`, "1:16"},
+		{"let s = \"${1 + true}\";", "1:12"},
+	}
+
+	for _, tt := range test {
+		evaluated := evalExpr(tt.input)
+
+		obj, ok := evaluated.(*object.Error)
+
+		if !ok {
+			t.Errorf("obj is not *objectError, got=%T(%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if obj.Pos.String() != tt.pos {
+			t.Errorf("wrong position for %q, expected=%q, got=%q", tt.input, tt.pos, obj.Pos)
+		}
+
+		if obj.Inspect() != tt.pos+": "+obj.Message {
+			t.Errorf("wrong Inspect(), expected=%q, got=%q", tt.pos+": "+obj.Message, obj.Inspect())
+		}
+	}
+}
+
+func TestErrorDiagnostic(t *testing.T) {
+	evaluated := evalExpr("let x = 1;\nlet y = x + true;")
+
+	obj, ok := evaluated.(*object.Error)
+
+	if !ok {
+		t.Fatalf("obj is not *objectError, got=%T(%+v)", evaluated, evaluated)
+	}
+
+	d := obj.Diagnostic()
+
+	if d.Message != "type mismatch: INTEGER + BOOLEAN" {
+		t.Errorf("d.Message wrong, got=%q", d.Message)
+	}
+
+	if d.Span.Start.String() != "2:9" || d.Span.End.String() != "2:17" {
+		t.Errorf("d.Span wrong, expected=%q-%q, got=%q-%q", "2:9", "2:17", d.Span.Start, d.Span.End)
+	}
+}
+
+func TestErrorStackTrace(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"5 + true", []string{}},
+		{
+			"let add = fn(a, b) {\n  a + b\n};\nlet apply = fn(f, x) { f(x, true) };\napply(add, 1);",
+			[]string{"in add, called at 4:24", "in apply, called at 5:1"},
+		},
+		{
+			"let f = fn() { fn() { foo }() };\nf()",
+			[]string{"in <anonymous>, called at 1:16", "in f, called at 2:1"},
+		},
+		{
+			"let countdown = fn(n) { if (n == 0) { n + true } else { countdown(n - 1) } };\ncountdown(2)",
+			[]string{"in countdown, called at 1:57", "in countdown, called at 1:57", "in countdown, called at 2:1"},
+		},
+	}
+
+	for _, tt := range tests {
+		evaluated := evalExpr(tt.input)
+
+		obj, ok := evaluated.(*object.Error)
+
+		if !ok {
+			t.Errorf("obj is not *objectError, got=%T(%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if len(obj.Stack) != len(tt.expected) {
+			t.Errorf("wrong stack size for %q, expected=%d, got=%d (%v)", tt.input, len(tt.expected), len(obj.Stack), obj.Stack)
+			continue
+		}
+
+		notes := obj.Diagnostic().Notes
+
+		for i, frame := range obj.Stack {
+			if frame.String() != tt.expected[i] {
+				t.Errorf("wrong frame, expected=%q, got=%q", tt.expected[i], frame.String())
+			}
+
+			if notes[i] != tt.expected[i] {
+				t.Errorf("wrong diagnostic note, expected=%q, got=%q", tt.expected[i], notes[i])
+			}
+		}
+	}
+}
+
 func TestLetEvaluation(t *testing.T) {
 	test := []struct {
 		input    string
@@ -186,6 +506,84 @@ func TestLetEvaluation(t *testing.T) {
 	}
 }
 
+func TestCommentedProgramEvaluation(t *testing.T) {
+	input := `
+	# Adds two numbers.
+	let add = fn(x, y) {
+		x + y // the sum
+	};
+	/* let add = fn(x, y) { x - y }; */
+	add(2, /* inline */ 3);
+	`
+
+	testIntegerObject(t, evalExpr(input), 5)
+}
+
+func TestLogicalOperators(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{"true && true", true},
+		{"true && false", false},
+		{"false && true", false},
+		{"true || false", true},
+		{"false || false", false},
+		{"false || true", true},
+		{"1 < 2 && 2 < 3", true},
+		{"1 > 2 || 2 > 3", false},
+		// The deciding operand is returned as is.
+		{"1 && 2", 2},
+		{"0 && 2", 2},
+		{"false && 2", false},
+		{"1 || 2", 1},
+		{"false || 5", 5},
+		{"if (false) { 1 } || 7", 7},
+		{"if (false) { 1 } && 7", nil},
+		{"false || false || 3", 3},
+	}
+
+	for _, tt := range tests {
+		evaluated := evalExpr(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case bool:
+			testBooleanObject(t, evaluated, expected)
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		default:
+			testNullObject(t, evaluated)
+		}
+	}
+}
+
+func TestLogicalOperatorsShortCircuit(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"let x = 0; false && (x = 1); x;", 0},
+		{"let x = 0; true && (x = 1); x;", 1},
+		{"let x = 0; true || (x = 1); x;", 0},
+		{"let x = 0; false || (x = 1); x;", 1},
+		{"let calls = 0; let f = fn() { calls += 1; true }; f() || f() || f(); calls;", 1},
+		{"let calls = 0; let f = fn() { calls += 1; false }; f() && f() && f(); calls;", 1},
+		// An error on the right side is not raised when it is never evaluated.
+		{"if (false && nope) { 1 } else { 2 }", 2},
+		{"if (true || 1 + true) { 1 } else { 2 }", 1},
+	}
+
+	for _, tt := range tests {
+		testIntegerObject(t, evalExpr(tt.input), tt.expected)
+	}
+
+	evaluated := evalExpr("true && nope")
+
+	if obj, ok := evaluated.(*object.Error); !ok || obj.Message != "identifier not found: nope" {
+		t.Errorf("expected identifier not found error, got=%T(%+v)", evaluated, evaluated)
+	}
+}
+
 func TestFuncLiteralEvaluation(t *testing.T) {
 	input := "fn (x) { x + 2; }"
 
@@ -229,50 +627,119 @@ func TestFuncCallEvaluation(t *testing.T) {
 	}
 }
 
-func TestStringEvaluation(t *testing.T) {
-	input := `"hello\nworld"`
+func TestClosures(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		// Returned closures keep the environment they were defined in.
+		{"let newAdder = fn(x) { fn(y) { x + y } }; let addTwo = newAdder(2); addTwo(3);", 5},
+		{"let newAdder = fn(x) { fn(y) { x + y } }; let addTwo = newAdder(2); let x = 100; addTwo(3);", 5},
+		{"let newAdder = fn(x) { fn(y) { x + y } }; let a = newAdder(1); let b = newAdder(10); a(1) + b(1);", 13},
+		{"let x = 1; let f = fn() { x }; let g = fn(x) { f() }; g(50);", 1},
+		// Functional counters, each step returns a new counter.
+		{"let counter = fn(n) { fn() { [n, counter(n + 1)] } }; let c = counter(0); c()[1]()[1]()[0];", 2},
+		// Currying.
+		{"let add = fn(a) { fn(b) { fn(c) { a + b + c } } }; add(1)(2)(3);", 6},
+		{"let curry = fn(f) { fn(a) { fn(b) { f(a, b) } } }; let mul = fn(a, b) { a * b }; curry(mul)(3)(4);", 12},
+		{"let compose = fn(f, g) { fn(x) { g(f(x)) } }; let inc = fn(x) { x + 1 }; let dbl = fn(x) { x * 2 }; compose(inc, dbl)(5);", 12},
+		// Recursion through let, at top level and inside a function body.
+		{"let fact = fn(n) { if (n == 0) { 1 } else { n * fact(n - 1) } }; fact(5);", 120},
+		{"let fib = fn(n) { if (n < 2) { n } else { fib(n - 1) + fib(n - 2) } }; fib(10);", 55},
+		{"let outer = fn() { let loop = fn(n, acc) { if (n == 0) { acc } else { loop(n - 1, acc + n) } }; loop(4, 0) }; outer();", 10},
+		{"let isEven = fn(n) { if (n == 0) { true } else { isOdd(n - 1) } }; let isOdd = fn(n) { if (n == 0) { false } else { isEven(n - 1) } }; if (isEven(10)) { 1 } else { 0 };", 1},
+		// Shadowing across nested functions.
+		{"let x = 1; let f = fn(x) { fn(x) { x } }; f(2)(3);", 3},
+		{"let x = 1; let f = fn(x) { fn(y) { x } }; f(2)(3);", 2},
+		{"let x = 1; let f = fn() { let x = 2; fn() { x } }; f()() + x;", 3},
+		{"let x = 1; let f = fn() { let x = x + 10; x }; f() + x;", 12},
+		{"let f = fn(a) { let g = fn(a) { a * 10 }; g(a + 1) + a }; f(1);", 21},
+	}
+
+	for _, tt := range tests {
+		testIntegerObject(t, evalExpr(tt.input), tt.expected)
+	}
+}
+
+func TestClosuresDoNotSeeCallerScope(t *testing.T) {
+	input := "let f = fn() { y }; let g = fn(y) { f() }; g(1);"
 
 	evaluated := evalExpr(input)
 
-	str, ok := evaluated.(*object.String)
+	obj, ok := evaluated.(*object.Error)
 
 	if !ok {
-		t.Errorf("evaluated is not *object.String, got=%T (%+v)", evaluated, evaluated)
+		t.Fatalf("obj is not *objectError, got=%T(%+v)", evaluated, evaluated)
 	}
 
-	inputEnquoted, _ := strconv.Unquote(input)
+	if obj.Message != "identifier not found: y" {
+		t.Errorf("wrong message, expected=%q, got=%q", "identifier not found: y", obj.Message)
+	}
+}
 
-	if str.Inspect() != inputEnquoted {
-		t.Errorf("String evaluated is not %q, got=%q", inputEnquoted, str.Inspect())
+func TestFuncCallArity(t *testing.T) {
+	tests := []struct {
+		input string
+		err   string
+	}{
+		{"let add = fn(x, y) { x + y; }; add(1);", "function add expects 2 arguments, got 1"},
+		{"let add = fn(x, y) { x + y; }; add();", "function add expects 2 arguments, got 0"},
+		{"let add = fn(x, y) { x + y; }; add(1, 2, 3);", "function add expects 2 arguments, got 3"},
+		{"let identity = fn(x) { x }; identity();", "function identity expects 1 argument, got 0"},
+		{"let identity = fn(x) { x }; identity(1, 2);", "function identity expects 1 argument, got 2"},
+		{"let answer = fn() { 42 }; answer(1);", "function answer expects 0 arguments, got 1"},
+		{"fn(x) { x }();", "function <anonymous> expects 1 argument, got 0"},
+		{"let newAdder = fn(x) { fn(y) { x + y } }; newAdder(1)(2, 3);", "function <anonymous> expects 1 argument, got 2"},
+		{"let apply = fn(f) { f(1) }; let add = fn(x, y) { x + y }; apply(add);", "function add expects 2 arguments, got 1"},
+		{`len()`, "wrong number of arguments, got=0, want=1"},
+		{`len([1], [2])`, "wrong number of arguments, got=2, want=1"},
+		{`first()`, "wrong number of arguments, got=0, want=1"},
+		{`first([1], [2])`, "wrong number of arguments, got=2, want=1"},
+		{`rest()`, "wrong number of arguments, got=0, want=1"},
+		{`rest([1], [2])`, "wrong number of arguments, got=2, want=1"},
+		{`push([1])`, "wrong number of arguments, got=1, want=2"},
+		{`push([1], 2, 3)`, "wrong number of arguments, got=3, want=2"},
 	}
 
-}
+	for _, tt := range tests {
+		evaluated := evalExpr(tt.input)
 
-func TestStringConcatenation(t *testing.T) {
-	input := `"Hello" + " " + "World!"`
+		obj, ok := evaluated.(*object.Error)
 
-	evaluated := evalExpr(input)
+		if !ok {
+			t.Errorf("obj is not *objectError, got=%T(%+v)", evaluated, evaluated)
+			continue
+		}
 
-	str, ok := evaluated.(*object.String)
+		if obj.Message != tt.err {
+			t.Errorf("wrong message, expected=%q, got=%q", tt.err, obj.Message)
+		}
+	}
+}
 
-	if !ok {
-		t.Errorf("evaluated is not *object.String, got=%T (%+v)", evaluated, evaluated)
+func TestFuncCallWithoutValue(t *testing.T) {
+	tests := []string{
+		"fn() {}();",
+		"let f = fn() { let x = 1; }; f();",
 	}
 
-	if str.Inspect() != "Hello World!" {
-		t.Errorf("String evaluated is not %q, got=%q", "Hello World!", str.Value)
+	for _, input := range tests {
+		testNullObject(t, evalExpr(input))
 	}
 }
 
-func TestLenBuiltFunction(t *testing.T) {
+func TestWhileStatement(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected any
 	}{
-		{`len("")`, 0},
-		{`len("Hello World")`, 11},
-		{`len("Hello", "Hello")`, "wrong number of arguments, got=2, want=1"},
-		{`len(1)`, "argument to 'len' is not supported, got=INTEGER"},
+		{"let i = 0; while (i < 5) { let i = i + 1; }; i;", 5},
+		{"let i = 0; let sum = 0; while (i < 10) { let i = i + 1; let sum = sum + i; }; sum;", 55},
+		{"let i = 0; while (false) { let i = 1; }; i;", 0},
+		{"let i = 0; while (true) { let i = i + 1; if (i == 3) { break; } }; i;", 3},
+		{"let i = 0; let odd = 0; while (i < 10) { let i = i + 1; if (i / 2 * 2 == i) { continue; } let odd = odd + 1; }; odd;", 5},
+		{"let i = 0; while (i < 100000) { let i = i + 1; }; i;", 100000},
+		{"let i = 0; while (i < 3) { let i = i + 1; }", nil},
 	}
 
 	for _, tt := range tests {
@@ -282,74 +749,698 @@ func TestLenBuiltFunction(t *testing.T) {
 		case int:
 			testIntegerObject(t, evaluated, int64(expected))
 		default:
-			errObj, ok := evaluated.(*object.Error)
-
-			if !ok {
-				t.Errorf("object is not *object.Error, got=%T (%+v)", evaluated, evaluated)
-				continue
-			}
-
-			if errObj.Message != tt.expected {
-				t.Fatalf("Error.Message is not %q, got=%q", expected, errObj.Message)
+			if evaluated != nil {
+				t.Errorf("loop statement produced a value, got=%T (%+v)", evaluated, evaluated)
 			}
-
 		}
-
 	}
 }
 
-func TestArrayExpression(t *testing.T) {
-	input := "[2+4, 4, 10-1]"
+func TestForStatement(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"let sum = 0; for (x in [1, 2, 3, 4]) { sum = sum + x; }; sum;", 10},
+		{"let sum = 0; for (x in []) { sum = sum + x; }; sum;", 0},
+		{`let count = 0; for (ch in "hello") { count = count + 1; }; count;`, 5},
+		{`let out = ""; for (ch in "abc") { out = ch + out; }; len(out);`, 3},
+		{`let sum = 0; for (k in {1: "a", 2: "b", 3: "c"}) { sum = sum + k; }; sum;`, 6},
+		{"let sum = 0; for (x in [1, 2, 3, 4]) { if (x == 3) { break; } sum = sum + x; }; sum;", 3},
+		{"let sum = 0; for (x in [1, 2, 3, 4]) { if (x == 3) { continue; } sum = sum + x; }; sum;", 7},
+		{"let x = 42; for (x in [1, 2, 3]) { x }; x;", 42},
+		{"let x = 42; for (y in [1, 2, 3]) { let x = y; }; x;", 42},
+		{`let n = 0; for (ch in "banana") { if (ch == "a") { n += 1 } }; n;`, 3},
+	}
 
-	evaluated := evalExpr(input)
+	for _, tt := range tests {
+		testIntegerObject(t, evalExpr(tt.input), tt.expected)
+	}
+}
 
-	arr, ok := evaluated.(*object.Array)
+func TestNestedLoops(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"let sum = 0; for (x in [1, 2, 3]) { for (y in [10, 20]) { sum = sum + x * y; } }; sum;", 180},
+		{"let n = 0; for (x in [1, 2, 3]) { for (y in [1, 2, 3]) { if (y > x) { break; } n = n + 1; } }; n;", 6},
+		{"let n = 0; for (x in [1, 2, 3]) { for (y in [1, 2, 3]) { if (y == x) { continue; } n = n + 1; } }; n;", 6},
+		{"let i = 0; let n = 0; while (i < 3) { let i = i + 1; let j = 0; while (j < i) { let j = j + 1; let n = n + 1; } }; n;", 6},
+		{"let n = 0; for (x in [1, 2, 3]) { let i = 0; while (true) { let i = i + 1; if (i > x) { break; } n = n + 1; } }; n;", 6},
+	}
 
-	if !ok {
-		t.Errorf("evaluated is not *object.Array, got=%T(%+v)", evaluated, evaluated)
+	for _, tt := range tests {
+		testIntegerObject(t, evalExpr(tt.input), tt.expected)
 	}
+}
 
-	if arr.Inspect() != "[6, 4, 9]" {
-		t.Errorf("Array evaluated is not %q, got=%q", "[6, 4, 9]", arr.Inspect())
+func TestLoopSignalsInExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		// break and continue cut short the expression they appear in, like an
+		// error would, instead of becoming its value.
+		{"let n = 0; for (i in [1, 2, 3]) { let x = if (i == 2) { break; } else { 0 }; n += 1 }; n;", 1},
+		{"let n = 0; for (i in [1, 2, 3]) { let x = if (i == 2) { continue; } else { i }; n += x }; n;", 4},
+		{"let out = []; for (i in [1, 2, 3]) { out = push(out, if (i == 2) { continue; } else { i }) }; len(out);", 2},
+		{"let out = []; for (i in [1, 2, 3]) { out = push(out, if (i == 2) { break; } else { i }) }; len(out);", 1},
+		{"let n = 0; let add = fn(a, b) { a + b }; for (i in [1, 2, 3]) { n = add(n, if (i == 2) { continue; } else { i }) }; n;", 4},
+		{"let n = 0; for (i in [1, 2, 3]) { n += 10 * (if (i == 3) { break; } else { i }) }; n;", 30},
+		{"let n = 0; while (n < 10) { n += 1; -(if (n == 4) { break; } else { n }) }; n;", 4},
+		{"let n = 0; for (i in [1, 2, 3]) { let a = [i, if (i == 2) { continue; } else { i }]; n += len(a) }; n;", 4},
+		{`let n = 0; for (i in [1, 2, 3]) { let h = {"i": if (i == 3) { break; } else { i }}; n += h["i"] }; n;`, 3},
+		{"let n = 0; for (i in [1, 2, 3]) { n += [10, 20, 30][if (i == 2) { continue; } else { i - 1 }] }; n;", 40},
+		{`let n = 0; for (i in [1, 2, 3]) { let s = "${if (i == 2) { break; } else { i }}"; n += 1 }; n;`, 1},
+		{"let n = 0; for (i in [1, 2, 3]) { let a = [0]; a[0] = if (i == 2) { continue; } else { i }; n += a[0] }; n;", 4},
+		// A return in a let value returns from the function.
+		{"let f = fn() { let x = if (true) { return 5; } else { 0 }; 10 }; f();", 5},
+		{"let f = fn(g) { g(if (true) { return 7; } else { 0 }) }; f(fn(x) { x * 2 });", 7},
 	}
 
-	testIntegerObject(t, arr.Elements[0], 6)
-	testIntegerObject(t, arr.Elements[1], 4)
-	testIntegerObject(t, arr.Elements[2], 9)
+	for _, tt := range tests {
+		testIntegerObject(t, evalExpr(tt.input), tt.expected)
+	}
 }
 
-func TestArrayIndexExpressions(t *testing.T) {
+func TestLoopsAndClosures(t *testing.T) {
 	tests := []struct {
 		input    string
-		expected any
+		expected int64
 	}{
-		{"[1, 2, 3][0]", 1},
-		{"[1, 2, 3][1]", 2},
-		{"[1, 2, 3][2]", 3},
-		{"let i = 0; [1][i];", 1},
-		{"[1, 2, 3][1 + 1]", 3},
-		{"let arr = [1, 2, 3]; arr[2];", 3},
-		{"let arr = [[1,2,3],[3,2,3]]; arr[0][1]", 2},
-		{"[1, 2, 3][3]", nil},
+		// Each closure created in the body keeps its own loop variable, and so do
+		// the lets of that iteration.
+		{"let fs = []; for (x in [1, 2, 3]) { fs = push(fs, fn() { x * 10 }); }; fs[0]() + fs[2]();", 40},
+		{"let fs = []; for (x in [1, 2, 3]) { let y = x * 10; fs = push(fs, fn() { y }); }; fs[0]() + fs[1]();", 30},
+		{"let fs = []; for (x in [1, 2, 3]) { fs = push(fs, fn(y) { fn() { y * 10 } }(x)); }; fs[0]() + fs[2]();", 40},
+		// return inside a loop returns from the enclosing function.
+		{"let find = fn(xs, want) { for (x in xs) { if (x == want) { return x * 100; } }; -1 }; find([1, 2, 3], 2);", 200},
+		{"let find = fn(xs, want) { for (x in xs) { if (x == want) { return x * 100; } }; -1 }; find([1, 2, 3], 5);", -1},
+		{"let sum = fn(n) { let i = 0; let s = 0; while (true) { let i = i + 1; if (i > n) { return s; } let s = s + i; } }; sum(4);", 10},
+		// Loops inside closures.
+		{"let makeSum = fn(xs) { fn() { let s = 0; for (x in xs) { s = s + x; }; s } }; makeSum([1, 2, 3])();", 6},
+		{"let apply = fn(f) { let n = 0; for (x in [1, 2, 3]) { n = n + f(x); }; n }; apply(fn(x) { x * x });", 14},
 	}
 
 	for _, tt := range tests {
-		evaluated := evalExpr(tt.input)
-		integer, ok := tt.expected.(int)
-
-		if ok {
-			testIntegerObject(t, evaluated, int64(integer))
-		} else {
-			testNullObject(t, evaluated)
-		}
+		testIntegerObject(t, evalExpr(tt.input), tt.expected)
 	}
 }
 
-func TestHashObjectExpression(t *testing.T) {
-	input := `let two = "two";
-	{
-		   "one": 10 - 9,
-		   two: 1 + 1,
+func TestLoopErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		err   string
+	}{
+		{"for (x in 5) { x }", "INTEGER is not iterable"},
+		{"while (foo) { 1 }", "identifier not found: foo"},
+		{"for (x in [1, 2]) { x + true }", "type mismatch: INTEGER + BOOLEAN"},
+		{"let i = 0; while (i < 2) { let i = i + 1; if (i == 2) { -true } }", "unknown operator: -BOOLEAN"},
+	}
+
+	for _, tt := range tests {
+		evaluated := evalExpr(tt.input)
+
+		obj, ok := evaluated.(*object.Error)
+
+		if !ok {
+			t.Errorf("obj is not *objectError, got=%T(%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if obj.Message != tt.err {
+			t.Errorf("wrong message, expected=%q, got=%q", tt.err, obj.Message)
+		}
+	}
+}
+
+func TestAssignExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{"let x = 1; x = 2; x;", 2},
+		{"let x = 1; x = 2;", 2},
+		{"let x = 1; x += 2; x;", 3},
+		{"let x = 10; x -= 4; x;", 6},
+		{"let x = 3; x *= 4; x;", 12},
+		{"let x = 12; x /= 4; x;", 3},
+		{"let x = 1; x += 0.5; x;", 1.5},
+		{`let s = "foo"; s += "bar"; s;`, "foobar"},
+		{"let x = 1; let y = 2; x = y = 5; x + y;", 10},
+		{"let x = 1; let f = fn() { x = 10; }; f(); x;", 10},
+		{"let x = 1; let f = fn() { let x = 2; x = 10; }; f(); x;", 1},
+		{"let x = 1; let f = fn(x) { x = 10; x }; f(5) + x;", 11},
+		{"let x = 1; let f = fn() { fn() { x += 1 } }; let g = f(); g(); g(); x;", 3},
+		{"let counter = fn() { let n = 0; fn() { n += 1 } }; let c = counter(); c(); c(); c();", 3},
+		{"let counter = fn() { let n = 0; fn() { n += 1 } }; let a = counter(); let b = counter(); a(); a(); b();", 1},
+		{"let sum = 0; for (x in [1, 2, 3]) { sum += x; }; sum;", 6},
+		{"let i = 0; while (i < 10) { i += 1; }; i;", 10},
+	}
+
+	for _, tt := range tests {
+		evaluated := evalExpr(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case float64:
+			testFloatObject(t, evaluated, expected)
+		case string:
+			str, ok := evaluated.(*object.String)
+
+			if !ok {
+				t.Errorf("evaluated is not *object.String, got=%T (%+v)", evaluated, evaluated)
+				continue
+			}
+
+			if str.Value != expected {
+				t.Errorf("String evaluated is not %q, got=%q", expected, str.Value)
+			}
+		}
+	}
+}
+
+func TestAssignExpressionErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		err   string
+	}{
+		{"x = 1;", "assignment to undeclared identifier: x"},
+		{"x += 1;", "assignment to undeclared identifier: x"},
+		{"let f = fn() { y = 1 }; f();", "assignment to undeclared identifier: y"},
+		{"len = 1;", "assignment to undeclared identifier: len"},
+		{"let x = 1; x += true;", "type mismatch: INTEGER + BOOLEAN"},
+		{"let x = 1; x = foo;", "identifier not found: foo"},
+	}
+
+	for _, tt := range tests {
+		evaluated := evalExpr(tt.input)
+
+		obj, ok := evaluated.(*object.Error)
+
+		if !ok {
+			t.Errorf("obj is not *objectError, got=%T(%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if obj.Message != tt.err {
+			t.Errorf("wrong message, expected=%q, got=%q", tt.err, obj.Message)
+		}
+	}
+}
+
+func TestIndexAssignExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let a = [1, 2, 3]; a[0] = 10; a;", "[10, 2, 3]"},
+		{"let a = [1, 2, 3]; a[2] = 10;", "10"},
+		{"let a = [1, 2, 3]; a[1 + 1] = a[0] + 10; a;", "[1, 2, 11]"},
+		{"let a = [1, 2, 3]; a[1] += 5; a[1] *= 2; a;", "[1, 14, 3]"},
+		{"let m = [[1, 2], [3, 4]]; m[1][0] = 30; m;", "[[1, 2], [30, 4]]"},
+		{`let h = {"a": 1}; h["a"] = 2; h["a"];`, "2"},
+		{`let h = {}; h["b"] = 3; h["b"];`, "3"},
+		{`let h = {}; h[1] = "one"; h[true] = "yes"; h[1] + h[true];`, "oneyes"},
+		{`let h = {"n": 1}; h["n"] += 1; h["n"];`, "2"},
+		{`let h = {"list": [1]}; h["list"][0] = 5; h["list"];`, "[5]"},
+		{"let a = [0, 0, 0]; for (i in [0, 1, 2]) { a[i] = i * i; }; a;", "[0, 1, 4]"},
+		{"let set = fn(arr, i, v) { arr[i] = v; }; let a = [1, 2]; set(a, 0, 7); a;", "[7, 2]"},
+		{"let a = [1, 2, 3]; a[-1] = 30; a[-3] += 9; a;", "[10, 2, 30]"},
+	}
+
+	for _, tt := range tests {
+		evaluated := evalExpr(tt.input)
+
+		if evaluated == nil || evaluated.Inspect() != tt.expected {
+			t.Errorf("wrong result for %q, expected=%q, got=%+v", tt.input, tt.expected, evaluated)
+		}
+	}
+}
+
+// Arrays and hashes are mutable reference values: an index assignment is seen
+// through every binding of the same value, while builtins that build arrays
+// return copies that do not share storage with their argument.
+func TestIndexAssignMutability(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let a = [1, 2]; let b = a; b[0] = 9; a;", "[9, 2]"},
+		{`let h = {"k": 1}; let g = h; g["k"] = 2; h["k"];`, "2"},
+		{"let a = [1, 2]; let f = fn() { a[1] = 5; }; f(); a;", "[1, 5]"},
+		{"let a = [1, 2]; let b = push(a, 3); b[0] = 9; a;", "[1, 2]"},
+		{"let a = [1, 2]; let b = push(a, 3); a[0] = 9; b;", "[1, 2, 3]"},
+		{"let a = [1, 2, 3]; let r = rest(a); r[0] = 9; a;", "[1, 2, 3]"},
+		{"let a = [1, 2]; let b = [a, a]; a[0] = 5; b;", "[[5, 2], [5, 2]]"},
+	}
+
+	for _, tt := range tests {
+		evaluated := evalExpr(tt.input)
+
+		if evaluated == nil || evaluated.Inspect() != tt.expected {
+			t.Errorf("wrong result for %q, expected=%q, got=%+v", tt.input, tt.expected, evaluated)
+		}
+	}
+}
+
+func TestCyclicContainers(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let a = [1]; a[0] = a; a;", "[[...]]"},
+		{"let a = [1, 2]; a[1] = a; a;", "[1, [...]]"},
+		{"let a = [1]; let b = [a]; a[0] = b; a;", "[[[...]]]"},
+		{`let h = {"k": 1}; h["self"] = h; h;`, `{k: 1, self: {...}}`},
+		{`let h = {}; let a = [h]; h["a"] = a; a;`, `[{a: [...]}]`},
+		{"let a = [1]; a[0] = a; len(a);", "1"},
+		{`let a = [1]; a[0] = a; "${a}";`, "[[...]]"},
+		{`let a = [1]; a[0] = a; [a, a].join(" ");`, "[[...]] [[...]]"},
+		// A container shared without a cycle is printed in full each time.
+		{"let a = [1]; [a, [a]];", "[[1], [[1]]]"},
+		{"let a = [1]; a[0] = a; a == a;", "true"},
+		{"let a = [1]; a[0] = a; let b = [1]; b[0] = b; a == b;", "true"},
+		{"let a = [1, 2]; a[0] = a; let b = [1, 3]; b[0] = b; a == b;", "false"},
+		{"let a = [1]; a[0] = a; a == [a];", "true"},
+		{`let h = {}; h["h"] = h; let g = {}; g["h"] = g; h == g;`, "true"},
+		{`let h = {"n": 1}; h["h"] = h; let g = {"n": 2}; g["h"] = g; h != g;`, "true"},
+	}
+
+	for _, tt := range tests {
+		evaluated := evalExpr(tt.input)
+
+		if evaluated == nil || evaluated.Inspect() != tt.expected {
+			t.Errorf("wrong result for %q, expected=%q, got=%+v", tt.input, tt.expected, evaluated)
+		}
+	}
+}
+
+func TestCyclicHashKeys(t *testing.T) {
+	tests := []struct {
+		input string
+		err   string
+	}{
+		{"let a = [1]; a[0] = a; {a: 1};", "key is not a Hashable object, got=ARRAY_OBJ holding itself at [0]"},
+		{"let a = [1]; a[0] = a; let h = {}; h[a] = 1;", "key is not a Hashable object, got=ARRAY_OBJ holding itself at [0]"},
+		{"let a = [1]; a[0] = a; {}[a];", "key is not a Hashable object, got=ARRAY_OBJ holding itself at [0]"},
+		{"let a = [1]; a[0] = a; {}.has(a);", "key is not a Hashable object, got=ARRAY_OBJ holding itself at [0]"},
+		{"let a = [[1], 2]; a[0][0] = a[0]; {a: 1};", "key is not a Hashable object, got=ARRAY_OBJ holding itself at [0][0]"},
+		{`let h = {}; h["h"] = h; {[h]: 1};`, "key is not a Hashable object, got=ARRAY_OBJ with HASH at [0]"},
+	}
+
+	for _, tt := range tests {
+		evaluated := evalExpr(tt.input)
+
+		obj, ok := evaluated.(*object.Error)
+
+		if !ok {
+			t.Errorf("obj is not *objectError, got=%T(%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if obj.Message != tt.err {
+			t.Errorf("wrong message, expected=%q, got=%q", tt.err, obj.Message)
+		}
+	}
+}
+
+func TestStringIndexExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{`"abc"[0]`, "a"},
+		{`"abc"[2]`, "c"},
+		{`"abc"[-1]`, "c"},
+		{`"abc"[-3]`, "a"},
+		{`"abc"[3]`, nil},
+		{`"abc"[-4]`, nil},
+		{`""[0]`, nil},
+		{`"olá"[2]`, "á"},
+		{`"日本語"[-2]`, "本"},
+		{`"a😀b"[1]`, "😀"},
+		{`let s = "hello"; s[len(s) - 1] == s[-1]`, true},
+		{`let s = "hi"; s[0] + s[1] + s[0]`, "hih"},
+		{`"abc"[1][0]`, "b"},
+		{`first("abc")`, "a"},
+		{`first("éa")`, "é"},
+		{`first("")`, nil},
+		{`rest("abc")`, "bc"},
+		{`rest("€uro")`, "uro"},
+		{`rest("a")`, ""},
+		{`rest("")`, nil},
+		{`"abc".first()`, "a"},
+		{`"abc".rest().first()`, "b"},
+		{`len(rest("日本語"))`, 2},
+		{`let rev = fn(s) { if (len(s) == 0) { "" } else { rev(rest(s)) + first(s) } }; rev("olá!")`, "!álo"},
+		{`let count = fn(s, ch) { if (len(s) == 0) { 0 } else { count(rest(s), ch) + (if (first(s) == ch) { 1 } else { 0 }) } }; count("banana", "a")`, 3},
+	}
+
+	for _, tt := range tests {
+		evaluated := evalExpr(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case string:
+			str, ok := evaluated.(*object.String)
+
+			if !ok || str.Value != expected {
+				t.Errorf("%s: expected %q, got=%T(%+v)", tt.input, expected, evaluated, evaluated)
+			}
+		case bool:
+			testBooleanObject(t, evaluated, expected)
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		default:
+			testNullObject(t, evaluated)
+		}
+	}
+}
+
+func TestSliceExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"[1, 2, 3, 4, 5][1:3]", "[2, 3]"},
+		{"[1, 2, 3, 4, 5][:2]", "[1, 2]"},
+		{"[1, 2, 3, 4, 5][3:]", "[4, 5]"},
+		{"[1, 2, 3, 4, 5][:]", "[1, 2, 3, 4, 5]"},
+		{"[1, 2, 3, 4, 5][-2:]", "[4, 5]"},
+		{"[1, 2, 3, 4, 5][:-2]", "[1, 2, 3]"},
+		{"[1, 2, 3, 4, 5][-3:-1]", "[3, 4]"},
+		{"[1, 2, 3, 4, 5][1:-1]", "[2, 3, 4]"},
+		{"[1, 2, 3, 4, 5][2:2]", "[]"},
+		{"[1, 2, 3, 4, 5][3:1]", "[]"},
+		{"[1, 2, 3, 4, 5][-1:-3]", "[]"},
+		{"[1, 2, 3, 4, 5][0:100]", "[1, 2, 3, 4, 5]"},
+		{"[1, 2, 3, 4, 5][-100:2]", "[1, 2]"},
+		{"[1, 2, 3, 4, 5][-100:-50]", "[]"},
+		{"[1, 2, 3, 4, 5][50:100]", "[]"},
+		{"[1, 2, 3, 4, 5][5:]", "[]"},
+		{"[1, 2, 3, 4, 5][2 ** 64:]", "[]"},
+		{"[1, 2, 3, 4, 5][-(2 ** 64):1]", "[1]"},
+		{"[][:]", "[]"},
+		{"[][-1:1]", "[]"},
+		{"let i = 1; [1, 2, 3][i:i + 1]", "[2]"},
+		{"[[1, 2], [3, 4]][1:][0][:1]", "[3]"},
+		{`"hello"[1:3]`, "el"},
+		{`"hello"[:-1]`, "hell"},
+		{`"hello"[-3:]`, "llo"},
+		{`"hello"[10:]`, ""},
+		{`"hello"[3:1]`, ""},
+		{`"olá, 世界"[2:6]`, "á, 世"},
+		{`"😀ab"[:1]`, "😀"},
+		{`""[:]`, ""},
+		{"let n = null; n?[1:2]", "null"},
+	}
+
+	for _, tt := range tests {
+		evaluated := evalExpr(tt.input)
+
+		if evaluated == nil || evaluated.Inspect() != tt.expected {
+			t.Errorf("wrong result for %q, expected=%q, got=%+v", tt.input, tt.expected, evaluated)
+		}
+	}
+}
+
+func TestSliceIsACopy(t *testing.T) {
+	evaluated := evalExpr("let a = [1, 2, 3]; let b = a[:]; b[0] = 10; let c = a[1:]; push(c, 4); a")
+
+	if evaluated == nil || evaluated.Inspect() != "[1, 2, 3]" {
+		t.Errorf("slicing shares elements with the array, got=%+v", evaluated)
+	}
+}
+
+func TestSliceErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		err   string
+	}{
+		{"5[1:2]", "slice operation not supported, got=INTEGER"},
+		{`{"a": 1}[0:1]`, "slice operation not supported, got=HASH"},
+		{"null[:]", "slice operation not supported, got=NULL"},
+		{`[1, 2][1.0:]`, "slice index must be an integer, got=FLOAT"},
+		{`[1, 2][:"1"]`, "slice index must be an integer, got=STRING_OBJ"},
+		{`[1, 2][nope:]`, "identifier not found: nope"},
+		{`nope[:]`, "identifier not found: nope"},
+	}
+
+	for _, tt := range tests {
+		evaluated := evalExpr(tt.input)
+
+		obj, ok := evaluated.(*object.Error)
+
+		if !ok {
+			t.Errorf("obj is not *objectError, got=%T(%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if obj.Message != tt.err {
+			t.Errorf("wrong message, expected=%q, got=%q", tt.err, obj.Message)
+		}
+	}
+}
+
+func TestIndexAssignErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		err   string
+	}{
+		{"let a = [1, 2, 3]; a[3] = 1;", "index out of range: 3 with length 3"},
+		{"let a = []; a[0] = 1;", "index out of range: 0 with length 0"},
+		{"let a = [1, 2, 3]; a[-4] = 1;", "index out of range: -4 with length 3"},
+		{`let a = [1, 2, 3]; a["0"] = 1;`, "index assignment not supported: ARRAY_OBJ[STRING_OBJ]"},
+		{"let a = [1]; a[0] += true;", "type mismatch: INTEGER + BOOLEAN"},
+		{"let h = {}; h[fn(x) { x }] = 1;", "key is not a Hashable object, got=FUNCTION"},
+		{`let s = "abc"; s[0] = "x";`, "index assignment not supported: STRING_OBJ[INTEGER]"},
+		{"let n = 1; n[0] = 1;", "index assignment not supported: INTEGER[INTEGER]"},
+		{"nope[0] = 1;", "identifier not found: nope"},
+		{"let a = [1]; a[0] = nope;", "identifier not found: nope"},
+	}
+
+	for _, tt := range tests {
+		evaluated := evalExpr(tt.input)
+
+		obj, ok := evaluated.(*object.Error)
+
+		if !ok {
+			t.Errorf("obj is not *objectError, got=%T(%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if obj.Message != tt.err {
+			t.Errorf("wrong message, expected=%q, got=%q", tt.err, obj.Message)
+		}
+	}
+}
+
+func TestStringEvaluation(t *testing.T) {
+	input := `"hello\nworld"`
+
+	evaluated := evalExpr(input)
+
+	str, ok := evaluated.(*object.String)
+
+	if !ok {
+		t.Errorf("evaluated is not *object.String, got=%T (%+v)", evaluated, evaluated)
+	}
+
+	inputEnquoted, _ := strconv.Unquote(input)
+
+	if str.Inspect() != inputEnquoted {
+		t.Errorf("String evaluated is not %q, got=%q", inputEnquoted, str.Inspect())
+	}
+
+}
+
+func TestUnicodeStrings(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{`len("olá")`, 3},
+		{`len("日本語")`, 3},
+		{`len("😀")`, 1},
+		{`"olá".len()`, 3},
+		{`"ação".upper()`, "AÇÃO"},
+		{`let olá = "mundo"; olá`, "mundo"},
+		{`let 变量 = 2; 变量 * 3`, 6},
+		{`let _ñ = 1; _ñ`, 1},
+		{`"\u{1F600}"`, "😀"},
+		{`"\u{e9}t\u{E9}"`, "été"},
+		{`len("\u{1F600}")`, 1},
+		{`"caf\u00e9"`, "café"},
+		{`"a\x41"`, "aA"},
+		{`"\t\101\\"`, "\tA\\"},
+		{"`raw \\n ${x} \"q\"`", `raw \n ${x} "q"`},
+		{"`one\r\ntwo\n`", "one\ntwo\n"},
+		{"len(`日本`)", 2},
+		{`let n = 0; for (ch in "naïve") { n += 1 }; n`, 5},
+		{`let out = ""; for (ch in "ab€") { out = ch + out }; out`, "€ba"},
+	}
+
+	for _, tt := range tests {
+		evaluated := evalExpr(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			str, ok := evaluated.(*object.String)
+
+			if !ok || str.Value != expected {
+				t.Errorf("%s: expected %q, got=%T(%+v)", tt.input, expected, evaluated, evaluated)
+			}
+		}
+	}
+}
+
+func TestInterpolatedStrings(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let name = "Ana"; "Hello ${name}"`, "Hello Ana"},
+		{`let name = "Ana"; let items = [1, 2, 3]; "Hello ${name}, you have ${len(items)} items"`, "Hello Ana, you have 3 items"},
+		{`"${1 + 2}"`, "3"},
+		{`"${1}${2}"`, "12"},
+		{`"a${"b${"c"}d"}e"`, "abcde"},
+		{`"${ {"k": [1, 2]}["k"] }"`, "[1, 2]"},
+		{`"${ if (true) { "yes" } else { "no" } }!"`, "yes!"},
+		{`"${null} ${true} ${1.5}"`, "null true 1.5"},
+		{`let f = fn(x) { "<${x}>" }; f("a") + f(1)`, "<a><1>"},
+		{`"cost: \${price}"`, "cost: ${price}"},
+		{`"just $ and { }"`, "just $ and { }"},
+		{`let n = "olá"; "${n.upper()}: ${len(n)}"`, "OLÁ: 3"},
+	}
+
+	for _, tt := range tests {
+		evaluated := evalExpr(tt.input)
+		str, ok := evaluated.(*object.String)
+
+		if !ok || str.Value != tt.expected {
+			t.Errorf("%s: expected %q, got=%T(%+v)", tt.input, tt.expected, evaluated, evaluated)
+		}
+	}
+
+	evaluated := evalExpr(`"a ${nope} b"`)
+
+	if err, ok := evaluated.(*object.Error); !ok || err.Message != "identifier not found: nope" {
+		t.Errorf("expected identifier not found error, got=%T(%+v)", evaluated, evaluated)
+	}
+}
+
+func TestStringConcatenation(t *testing.T) {
+	input := `"Hello" + " " + "World!"`
+
+	evaluated := evalExpr(input)
+
+	str, ok := evaluated.(*object.String)
+
+	if !ok {
+		t.Errorf("evaluated is not *object.String, got=%T (%+v)", evaluated, evaluated)
+	}
+
+	if str.Inspect() != "Hello World!" {
+		t.Errorf("String evaluated is not %q, got=%q", "Hello World!", str.Value)
+	}
+}
+
+func TestLenBuiltFunction(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{`len("")`, 0},
+		{`len("Hello World")`, 11},
+		{`len("Hello", "Hello")`, "wrong number of arguments, got=2, want=1"},
+		{`len(1)`, "argument to 'len' is not supported, got=INTEGER"},
+	}
+
+	for _, tt := range tests {
+		evaluated := evalExpr(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		default:
+			errObj, ok := evaluated.(*object.Error)
+
+			if !ok {
+				t.Errorf("object is not *object.Error, got=%T (%+v)", evaluated, evaluated)
+				continue
+			}
+
+			if errObj.Message != tt.expected {
+				t.Fatalf("Error.Message is not %q, got=%q", expected, errObj.Message)
+			}
+
+		}
+
+	}
+}
+
+func TestArrayExpression(t *testing.T) {
+	input := "[2+4, 4, 10-1]"
+
+	evaluated := evalExpr(input)
+
+	arr, ok := evaluated.(*object.Array)
+
+	if !ok {
+		t.Errorf("evaluated is not *object.Array, got=%T(%+v)", evaluated, evaluated)
+	}
+
+	if arr.Inspect() != "[6, 4, 9]" {
+		t.Errorf("Array evaluated is not %q, got=%q", "[6, 4, 9]", arr.Inspect())
+	}
+
+	testIntegerObject(t, arr.Elements[0], 6)
+	testIntegerObject(t, arr.Elements[1], 4)
+	testIntegerObject(t, arr.Elements[2], 9)
+}
+
+func TestArrayIndexExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{"[1, 2, 3][0]", 1},
+		{"[1, 2, 3][1]", 2},
+		{"[1, 2, 3][2]", 3},
+		{"let i = 0; [1][i];", 1},
+		{"[1, 2, 3][1 + 1]", 3},
+		{"let arr = [1, 2, 3]; arr[2];", 3},
+		{"let arr = [[1,2,3],[3,2,3]]; arr[0][1]", 2},
+		{"[1, 2, 3][3]", nil},
+		{"[1, 2, 3][-1]", 3},
+		{"[1, 2, 3][-3]", 1},
+		{"[1, 2, 3][-4]", nil},
+		{"[][-1]", nil},
+		{"let arr = [1, 2, 3]; arr[len(arr) - 1] == arr[-1]", true},
+	}
+
+	for _, tt := range tests {
+		evaluated := evalExpr(tt.input)
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case bool:
+			testBooleanObject(t, evaluated, expected)
+		default:
+			testNullObject(t, evaluated)
+		}
+	}
+}
+
+func TestHashObjectExpression(t *testing.T) {
+	input := `let two = "two";
+	{
+		   "one": 10 - 9,
+		   two: 1 + 1,
 		   "thr" + "ee": 6 / 2,
 		   4: 4,
 		   true: 5,
@@ -363,28 +1454,58 @@ func TestHashObjectExpression(t *testing.T) {
 		t.Fatalf("evalutated is not *object.HashObject, got=%T(%+v)", evaluated, evaluated)
 	}
 
-	expected := map[object.HashSet]int64{
-		(&object.String{Value: "one"}).Hash():     1,
-		(&object.String{Value: "two"}).Hash():     2,
-		(&object.String{Value: "three"}).Hash():   3,
-		(&object.Integer{Value: int64(4)}).Hash(): 4,
-		TRUE.Hash():  5,
-		FALSE.Hash(): 6,
+	expected := []struct {
+		key   object.Hashable
+		value int64
+	}{
+		{&object.String{Value: "one"}, 1},
+		{&object.String{Value: "two"}, 2},
+		{&object.String{Value: "three"}, 3},
+		{&object.Integer{Value: int64(4)}, 4},
+		{TRUE, 5},
+		{FALSE, 6},
 	}
 
-	if len(hash.Value) != len(expected) {
-		t.Fatalf("invalid number of elements in map, expected=%d, got=%d", len(expected), len(hash.Value))
+	if hash.Len() != len(expected) {
+		t.Fatalf("invalid number of elements in map, expected=%d, got=%d", len(expected), hash.Len())
 	}
 
-	for k, v := range expected {
-		hashValue, ok := hash.Value[k]
+	for i, tt := range expected {
+		value, ok := hash.Get(tt.key)
 
 		if !ok {
-			t.Errorf("value not found with key %q", k)
+			t.Errorf("value not found with key %q", tt.key.Inspect())
 			continue
 		}
 
-		testIntegerObject(t, hashValue.Value, v)
+		testIntegerObject(t, value, tt.value)
+
+		if key := hash.Pairs()[i].Key; key.Inspect() != tt.key.Inspect() {
+			t.Errorf("hash.Pairs()[%d] has wrong key, expected=%q, got=%q", i, tt.key.Inspect(), key.Inspect())
+		}
+	}
+}
+
+func TestHashOrdering(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`{"b": 1, "a": 2, "c": 3}`, "{b: 1, a: 2, c: 3}"},
+		{`{3: "x", 1: "y", 2: "z"}`, "{3: x, 1: y, 2: z}"},
+		{`let h = {"b": 1, "a": 2}; h["c"] = 3; h["b"] = 4; h`, "{b: 4, a: 2, c: 3}"},
+		{`let h = {}; for (x in [5, 3, 9, 1]) { h[x] = x * x }; h`, "{5: 25, 3: 9, 9: 81, 1: 1}"},
+		{`let keys = ""; for (k in {"z": 1, "y": 2, "x": 3}) { keys += k }; keys`, "zyx"},
+		// Keys and values are evaluated left to right, in source order.
+		{`let log = ""; let f = fn(s) { log += s; s }; {f("a"): f("b"), f("c"): f("d")}; log`, "abcd"},
+	}
+
+	for _, tt := range tests {
+		evaluated := evalExpr(tt.input)
+
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got=%q", tt.input, tt.expected, evaluated.Inspect())
+		}
 	}
 }
 
@@ -421,6 +1542,26 @@ func TestHashIndexExpressions(t *testing.T) {
 			`{false: 5}[false]`,
 			5,
 		},
+		{
+			`{1.5: 5}[1.5]`,
+			5,
+		},
+		{
+			`{1: 5}[1.0]`,
+			5,
+		},
+		{
+			`{2.0: 5}[2]`,
+			5,
+		},
+		{
+			`{0: 5}[-0.0]`,
+			5,
+		},
+		{
+			`{1.5: 5}[1]`,
+			nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -435,6 +1576,246 @@ func TestHashIndexExpressions(t *testing.T) {
 	}
 }
 
+func TestNullAndOptionalAccess(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{"null", nil},
+		{"let x = null; x", nil},
+		{"null ?? 5", 5},
+		{"3 ?? 5", 3},
+		{"false ?? 5", false},
+		{"0 ?? 5", 0},
+		{"null ?? null ?? 7", 7},
+		{"null == null", true},
+		{"null != 1", true},
+		{"if (null) { 1 } else { 2 }", 2},
+		{`let cfg = {"db": {"port": 5432}}; cfg?.db?.port`, 5432},
+		{`let cfg = {"db": {"port": 5432}}; cfg?.cache?.port`, nil},
+		{`let cfg = {"db": {"port": 5432}}; cfg?.cache?.port ?? 6379`, 6379},
+		{`let cfg = null; cfg?.db`, nil},
+		{`let cfg = null; cfg?["db"]`, nil},
+		{`let arr = [1, 2]; arr?[1]`, 2},
+		{`let arr = null; arr?[1] ?? 0`, 0},
+		// The index is not evaluated when the left side is null.
+		{`let x = 0; null?[x = 1]; x`, 0},
+		{`let x = 0; null ?? (x = 1); x`, 1},
+		{`let x = 0; 5 ?? (x = 1); x`, 0},
+	}
+
+	for _, tt := range tests {
+		evaluated := evalExpr(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case bool:
+			testBooleanObject(t, evaluated, expected)
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		default:
+			testNullObject(t, evaluated)
+		}
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{"null[0]", "index operation not supported, got=NULL"},
+		{`let cfg = null; cfg?.db["port"]`, "index operation not supported, got=NULL"},
+		{"5?.x", "field access not supported: INTEGER.x"},
+		{"nope ?? 1", "identifier not found: nope"},
+	}
+
+	for _, tt := range errorTests {
+		evaluated := evalExpr(tt.input)
+		err, ok := evaluated.(*object.Error)
+
+		if !ok || err.Message != tt.expected {
+			t.Errorf("expected error %q for %q, got=%T(%+v)", tt.expected, tt.input, evaluated, evaluated)
+		}
+	}
+}
+
+func TestMemberExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{`let cfg = {"db": {"port": 5432}}; cfg.db.port`, 5432},
+		{`let cfg = {"db": {"port": 5432}}; cfg.db["port"]`, 5432},
+		{`let cfg = {"db": 1}; cfg.cache`, nil},
+		{`let cfg = {"db": {}}; cfg.db.port = 80; cfg["db"]["port"]`, 80},
+		{`let cfg = {"n": 1}; cfg.n += 2; cfg.n`, 3},
+		{`let cfg = {}; cfg.n = 1; cfg.n`, 1},
+		{`let cfg = null; cfg?.db?.port`, nil},
+		{`let cfg = {"db": null}; cfg.db?.port ?? 1`, 1},
+	}
+
+	for _, tt := range tests {
+		evaluated := evalExpr(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		default:
+			testNullObject(t, evaluated)
+		}
+	}
+}
+
+func TestMethodCalls(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{`"abc".upper()`, "ABC"},
+		{`"ABC".lower()`, "abc"},
+		{`"  abc ".trim()`, "abc"},
+		{`"abc".len()`, 3},
+		{`"a,b,c".split(",").len()`, 3},
+		{`"a,b,c".split(",").join("-")`, "a-b-c"},
+		{`"abc".contains("bc")`, true},
+		{`"abc".contains("x")`, false},
+		{`[1, 2, 3].len()`, 3},
+		{`[1, 2, 3].first()`, 1},
+		{`[1, 2, 3].rest().first()`, 2},
+		{`[1, 2].push(3).len()`, 3},
+		{`[1, 2, 3].join(", ")`, "1, 2, 3"},
+		{`{"a": 1, "b": 2}.len()`, 2},
+		{`{"a": 1}.has("a")`, true},
+		{`{"a": 1}.has("b")`, false},
+		{`let s = "x"; s.upper() + s`, "Xx"},
+		// A function stored in a hash is called in place of a HASH method, any
+		// other field is not.
+		{`let obj = {"len": fn() { 42 }}; obj.len()`, 42},
+		{`{"len": 5, "has": "x"}.len()`, 2},
+		{`{"has": true}.has("nope")`, false},
+		{`let counter = {"add": fn(a, b) { a + b }}; counter.add(2, 3)`, 5},
+		{`let s = null; s?.upper()`, nil},
+	}
+
+	for _, tt := range tests {
+		evaluated := evalExpr(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case string:
+			str, ok := evaluated.(*object.String)
+
+			if !ok || str.Value != expected {
+				t.Errorf("%s: expected %q, got=%T(%+v)", tt.input, expected, evaluated, evaluated)
+			}
+		case bool:
+			testBooleanObject(t, evaluated, expected)
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		default:
+			testNullObject(t, evaluated)
+		}
+	}
+}
+
+func TestMethodCallErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`"abc".nope()`, "unknown method nope for STRING_OBJ"},
+		{`5.upper()`, "unknown method upper for INTEGER"},
+		{`null.upper()`, "unknown method upper for NULL"},
+		{`"abc".upper(1)`, "wrong number of arguments, got=1, want=0"},
+		{`[1].push()`, "wrong number of arguments, got=0, want=1"},
+		{`"abc".split(1)`, "argument to 'split' is not supported, got=INTEGER"},
+		{`"abc".len`, "field access not supported: STRING_OBJ.len"},
+		{`let cfg = null; cfg?.db.port`, "field access not supported: NULL.port"},
+		{`let obj = {"f": 1}; obj.f()`, "unknown method f for HASH"},
+		{`let x = 5; x.y = 1`, "index assignment not supported: INTEGER[STRING_OBJ]"},
+		{`nope.upper()`, "identifier not found: nope"},
+		{`"abc".contains(nope)`, "identifier not found: nope"},
+	}
+
+	for _, tt := range tests {
+		evaluated := evalExpr(tt.input)
+		err, ok := evaluated.(*object.Error)
+
+		if !ok || err.Message != tt.expected {
+			t.Errorf("expected error %q for %q, got=%T(%+v)", tt.expected, tt.input, evaluated, evaluated)
+		}
+	}
+}
+
+func TestHashStringAndIntegerKeys(t *testing.T) {
+	// An integer equal to the string's hash value is still a different key.
+	integer := strconv.FormatInt(int64((&object.String{Value: "foo"}).Hash().Value), 10)
+	input := `let h = {"foo": 1, ` + integer + `: 2}; [h["foo"], h[` + integer + `], h.len()]`
+
+	arr, ok := evalExpr(input).(*object.Array)
+
+	if !ok {
+		t.Fatalf("object is not an Array, got=%T", evalExpr(input))
+	}
+
+	for i, expected := range []int64{1, 2, 2} {
+		testIntegerObject(t, arr.Elements[i], expected)
+	}
+}
+
+func TestArrayHashKeys(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{`{[1, 2]: "a"}[[1, 2]]`, "a"},
+		{`{[1, 2]: "a"}[[2, 1]]`, nil},
+		{`{[1, [2, 3]]: "a"}[[1, [2, 3]]]`, "a"},
+		{`{[]: "empty"}[[]]`, "empty"},
+		{`{[1, "x", true]: "a"}[[1.0, "x", true]]`, "a"},
+		{`let grid = {}; for (x in [0, 1]) { for (y in [0, 1]) { grid[[x, y]] = x * 2 + y } }; grid[[1, 0]]`, 2},
+		{`let grid = {[0, 0]: 1}; grid[[0, 0]] += 1; grid[[0, 0]]`, 2},
+		{`let p = [1, 2]; let h = {}; h[p] = "a"; p[0] = 5; h[[1, 2]]`, "a"},
+		{`let p = [1, 2]; let h = {}; h[p] = "a"; p[0] = 5; h[p]`, nil},
+		{`{[1, 2]: "a"}.has([1, 2])`, true},
+		{`{[1, 2]: "a", [3, 4]: "b"}`, "{[1, 2]: a, [3, 4]: b}"},
+	}
+
+	for _, tt := range tests {
+		evaluated := evalExpr(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case string:
+			if evaluated == nil || evaluated.Inspect() != expected {
+				t.Errorf("%s: expected %q, got=%T(%+v)", tt.input, expected, evaluated, evaluated)
+			}
+		case bool:
+			testBooleanObject(t, evaluated, expected)
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		default:
+			testNullObject(t, evaluated)
+		}
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{`{[1, fn() {}]: 1}`, "key is not a Hashable object, got=ARRAY_OBJ with FUNCTION at [1]"},
+		{`{}[[1, [2, {}]]]`, "key is not a Hashable object, got=ARRAY_OBJ with HASH at [1][1]"},
+		{`let h = {}; h[[len]] = 1`, "key is not a Hashable object, got=ARRAY_OBJ with BUILT_IN at [0]"},
+		{`{}.has([null])`, "key is not a Hashable object, got=ARRAY_OBJ with NULL at [0]"},
+		{`{[1, 2]: 1, [1.0, 2]: 2}`, "key \"[1.0, 2]\" exists in hash, got=\"[1.0, 2]\": 2"},
+	}
+
+	for _, tt := range errorTests {
+		evaluated := evalExpr(tt.input)
+		err, ok := evaluated.(*object.Error)
+
+		if !ok || err.Message != tt.expected {
+			t.Errorf("expected error %q for %q, got=%T(%+v)", tt.expected, tt.input, evaluated, evaluated)
+		}
+	}
+}
+
 func evalExpr(input string) object.Object {
 	l := lexer.New(input)
 	p := parser.New(l)
@@ -459,6 +1840,22 @@ func testIntegerObject(t *testing.T, obj object.Object, expected int64) bool {
 	return true
 }
 
+func testFloatObject(t *testing.T, obj object.Object, expected float64) bool {
+	floatObj, ok := obj.(*object.Float)
+
+	if !ok {
+		t.Errorf("obj is not *object.Float,  got=%T (%+v)", obj, obj)
+		return false
+	}
+
+	if floatObj.Value != expected {
+		t.Errorf("floatObj.Value is not expected %g, got=%g", expected, floatObj.Value)
+		return false
+	}
+
+	return true
+}
+
 func testBooleanObject(t *testing.T, obj object.Object, expected bool) bool {
 	boolObj, ok := obj.(*object.Boolean)
 
@@ -0,0 +1,225 @@
+package evaluator
+
+import (
+	"strings"
+
+	"github.com/rodmedeiross/monkey-interpreter/ast"
+	"github.com/rodmedeiross/monkey-interpreter/object"
+	"github.com/rodmedeiross/monkey-interpreter/token"
+)
+
+// method is called as receiver.name(args). Methods are looked up by the type of
+// the receiver, so a method can rely on the receiver having that type.
+type method func(receiver object.Object, args ...object.Object) object.Object
+
+var methods = map[object.ObjectType]map[string]method{
+	object.STRING_OBJ: {
+		"len":   builtInMethod("len", 0),
+		"first": builtInMethod("first", 0),
+		"rest":  builtInMethod("rest", 0),
+		"upper": func(receiver object.Object, args ...object.Object) object.Object {
+			if err := checkMethodArity(args, 0); err != nil {
+				return err
+			}
+
+			return &object.String{Value: strings.ToUpper(receiver.(*object.String).Value)}
+		},
+		"lower": func(receiver object.Object, args ...object.Object) object.Object {
+			if err := checkMethodArity(args, 0); err != nil {
+				return err
+			}
+
+			return &object.String{Value: strings.ToLower(receiver.(*object.String).Value)}
+		},
+		"trim": func(receiver object.Object, args ...object.Object) object.Object {
+			if err := checkMethodArity(args, 0); err != nil {
+				return err
+			}
+
+			return &object.String{Value: strings.TrimSpace(receiver.(*object.String).Value)}
+		},
+		"contains": func(receiver object.Object, args ...object.Object) object.Object {
+			if err := checkMethodArity(args, 1); err != nil {
+				return err
+			}
+
+			substr, ok := args[0].(*object.String)
+
+			if !ok {
+				return setError("argument to 'contains' is not supported, got=%s", args[0].Type())
+			}
+
+			return nativeBoolToBooleanObj(strings.Contains(receiver.(*object.String).Value, substr.Value))
+		},
+		"split": func(receiver object.Object, args ...object.Object) object.Object {
+			if err := checkMethodArity(args, 1); err != nil {
+				return err
+			}
+
+			sep, ok := args[0].(*object.String)
+
+			if !ok {
+				return setError("argument to 'split' is not supported, got=%s", args[0].Type())
+			}
+
+			parts := strings.Split(receiver.(*object.String).Value, sep.Value)
+			elems := make([]object.Object, len(parts))
+
+			for i, part := range parts {
+				elems[i] = &object.String{Value: part}
+			}
+
+			return &object.Array{Elements: elems}
+		},
+	},
+	object.ARRAY_OBJ: {
+		"len":   builtInMethod("len", 0),
+		"first": builtInMethod("first", 0),
+		"rest":  builtInMethod("rest", 0),
+		"push":  builtInMethod("push", 1),
+		"join": func(receiver object.Object, args ...object.Object) object.Object {
+			if err := checkMethodArity(args, 1); err != nil {
+				return err
+			}
+
+			sep, ok := args[0].(*object.String)
+
+			if !ok {
+				return setError("argument to 'join' is not supported, got=%s", args[0].Type())
+			}
+
+			elems := []string{}
+
+			for _, el := range receiver.(*object.Array).Elements {
+				elems = append(elems, el.Inspect())
+			}
+
+			return &object.String{Value: strings.Join(elems, sep.Value)}
+		},
+	},
+	object.HASH: {
+		"len": func(receiver object.Object, args ...object.Object) object.Object {
+			if err := checkMethodArity(args, 0); err != nil {
+				return err
+			}
+
+			return &object.Integer{Value: int64(receiver.(*object.HashObject).Len())}
+		},
+		"has": func(receiver object.Object, args ...object.Object) object.Object {
+			if err := checkMethodArity(args, 1); err != nil {
+				return err
+			}
+
+			key, ok := args[0].(object.Hashable)
+
+			if !ok {
+				return setError("key is not a Hashable object, got=%s", args[0].Type())
+			}
+
+			if err := checkArrayKey(key); err != nil {
+				return err
+			}
+
+			_, found := receiver.(*object.HashObject).Get(key)
+
+			return nativeBoolToBooleanObj(found)
+		},
+	},
+}
+
+// builtInMethod exposes the builtin called name as a method taking arity
+// arguments besides the receiver, which is passed as the first argument.
+func builtInMethod(name string, arity int) method {
+	return func(receiver object.Object, args ...object.Object) object.Object {
+		if err := checkMethodArity(args, arity); err != nil {
+			return err
+		}
+
+		return builtInFunctions[name].Fn(append([]object.Object{receiver}, args...)...)
+	}
+}
+
+func checkMethodArity(args []object.Object, want int) *object.Error {
+	if len(args) != want {
+		return setError("wrong number of arguments, got=%d, want=%d", len(args), want)
+	}
+
+	return nil
+}
+
+// evalMemberExpression reads obj.field, which is sugar for obj["field"] and so
+// only applies to hashes.
+func evalMemberExpression(node *ast.MemberExpression, env *object.Environment) object.Object {
+	receiver := Eval(node.Object, env)
+
+	if isAbrupt(receiver) {
+		return receiver
+	}
+
+	if node.Optional && receiver == NULL {
+		return NULL
+	}
+
+	hash, ok := receiver.(*object.HashObject)
+
+	if !ok {
+		return setError("field access not supported: %s.%s", receiver.Type(), node.Property.Value)
+	}
+
+	return evalHashIndexExpression(hash, &object.String{Value: node.Property.Value})
+}
+
+// evalMethodCall evaluates receiver.name(args). On a hash, a function stored
+// under "name" wins over the HASH methods, so hashes can carry their own
+// functions; any other field, and anything else, dispatches through the
+// methods table.
+func evalMethodCall(node *ast.CallExpression, member *ast.MemberExpression, env *object.Environment) object.Object {
+	receiver := Eval(member.Object, env)
+
+	if isAbrupt(receiver) {
+		return receiver
+	}
+
+	if member.Optional && receiver == NULL {
+		return NULL
+	}
+
+	args := evalExpressions(node.FunctionCallParameters, env)
+
+	if len(args) == 1 && isAbrupt(args[0]) {
+		return args[0]
+	}
+
+	name := member.Property.Value
+
+	if hash, ok := receiver.(*object.HashObject); ok {
+		if field, ok := hash.Get(&object.String{Value: name}); ok {
+			switch field.(type) {
+			case *object.Function, *object.BuiltIn:
+				return applyFunction(field, args, node.Pos())
+			}
+		}
+	}
+
+	fn, ok := methods[receiver.Type()][name]
+
+	if !ok {
+		return setError("unknown method %s for %s", name, receiver.Type())
+	}
+
+	return fn(receiver, args...)
+}
+
+// memberAsIndex rewrites obj.field as obj["field"], so a field assignment can
+// share the index assignment path.
+func memberAsIndex(member *ast.MemberExpression) *ast.IndexExpression {
+	return &ast.IndexExpression{
+		Token: token.Token{Type: token.LCOL, Literal: "[", Start: member.Token.Start, End: member.Token.End},
+		Left:  member.Object,
+		Index: &ast.StringExpression{
+			Token: member.Property.Token,
+			Value: member.Property.Value,
+		},
+		EndPos: member.EndPos,
+	}
+}
@@ -1,37 +1,72 @@
 package lexer
 
 import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
 	"github.com/rodmedeiross/monkey-interpreter/token"
 )
 
+// Lexer reads its input as UTF-8, one rune at a time. Offsets are in bytes,
+// columns in runes.
 type Lexer struct {
+	filename     string
 	input        string
 	position     int
 	readPosition int
-	ch           byte
+	ch           rune
+	line         int
+	column       int
+	comments     []*token.Token
+	templates    []int // Brace depth inside each open ${ interpolation
 }
 
 func New(input string) *Lexer {
-	l := &Lexer{input: input}
+	return NewWithFilename("", input)
+}
+
+// NewWithFilename creates a Lexer whose token positions report filename.
+func NewWithFilename(filename, input string) *Lexer {
+	l := &Lexer{filename: filename, input: input, line: 1}
 	l.readChar()
 	return l
 }
 
 func (l *Lexer) readChar() {
+	if l.ch == '\n' {
+		l.line++
+		l.column = 0
+	}
+
+	width := 1
+
 	if l.readPosition >= len(l.input) {
 		l.ch = 0
 	} else {
-		l.ch = l.input[l.readPosition]
+		l.ch, width = utf8.DecodeRuneInString(l.input[l.readPosition:])
 	}
 	l.position = l.readPosition
-	l.readPosition += 1
+	l.readPosition += width
+	l.column += 1
 }
 
-func (l *Lexer) peekChar() byte {
+func (l *Lexer) currPosition() token.Position {
+	return token.Position{
+		Filename: l.filename,
+		Offset:   l.position,
+		Line:     l.line,
+		Column:   l.column,
+	}
+}
+
+func (l *Lexer) peekChar() rune {
 	if l.readPosition >= len(l.input) {
 		return 0
 	} else {
-		return l.input[l.readPosition]
+		ch, _ := utf8.DecodeRuneInString(l.input[l.readPosition:])
+		return ch
 	}
 }
 
@@ -44,34 +79,273 @@ func (l *Lexer) readIdentifier() string {
 	return l.input[position:l.position]
 }
 
-func (l *Lexer) readString() string {
-	position := l.position + 1
+// readStringPart reads the text of a string literal up to its closing quote, or
+// up to a ${ that starts an interpolation, and decodes its escapes. l.ch is on
+// the opening quote, or on the } closing the previous interpolation when first
+// is false, and is left on the closing quote or on the { of ${.
+//
+// A string without interpolations is a STRING token. Otherwise the text before
+// the first ${ is a TEMPLATE_HEAD, the text between interpolations are
+// TEMPLATE_MIDDLEs and the text after the last one a TEMPLATE_TAIL, with the
+// tokens of the embedded expressions in between.
+//
+// A string that reaches a newline or the end of the input is unterminated and
+// yields an ERROR token. So does an invalid escape, once the string around it
+// has been read, so that lexing resumes after the closing quote.
+func (l *Lexer) readStringPart(first bool) *token.Token {
+	var out strings.Builder
+	var escapeErr *token.Token
 
 	for {
 		l.readChar()
 
-		if l.ch == '\\' && isScapeChar(l.peekChar()) {
+		switch {
+		case l.ch == '"':
+			if escapeErr != nil {
+				return escapeErr
+			}
+			if first {
+				return &token.Token{Type: token.STRING, Literal: out.String()}
+			}
+			return &token.Token{Type: token.TEMPLATE_TAIL, Literal: out.String()}
+		case l.ch == '\n' || l.ch == 0:
+			return &token.Token{Type: token.ERROR, Literal: "unterminated string literal"}
+		case l.ch == '$' && l.peekChar() == '{':
 			l.readChar()
-			continue
+			l.templates = append(l.templates, 0)
+
+			if escapeErr != nil {
+				return escapeErr
+			}
+			if first {
+				return &token.Token{Type: token.TEMPLATE_HEAD, Literal: out.String()}
+			}
+			return &token.Token{Type: token.TEMPLATE_MIDDLE, Literal: out.String()}
+		case l.ch == '\\':
+			if err := l.readEscape(&out); err != nil && escapeErr == nil {
+				escapeErr = err
+			}
+		default:
+			out.WriteRune(l.ch)
 		}
+	}
+}
 
-		if l.ch == '"' || l.ch == 0 {
-			break
+// readEscape decodes the escape sequence whose backslash is under l.ch and
+// leaves l.ch on its last character. Besides Go's escapes, \u{...} takes a code
+// point of 1 to 6 hex digits and \$ is a dollar sign, so "\${" is not an
+// interpolation. A sequence that is not a valid escape yields an ERROR token.
+func (l *Lexer) readEscape(out *strings.Builder) *token.Token {
+	switch l.peekChar() {
+	case '$':
+		l.readChar()
+		out.WriteRune('$')
+		return nil
+	case 'u':
+		if strings.HasPrefix(l.input[l.position:], "\\u{") {
+			ch, ok := l.readBracedEscape()
+			if !ok {
+				return l.escapeError()
+			}
+			out.WriteRune(ch)
+			return nil
 		}
 	}
 
-	return l.input[position:l.position]
+	value, multibyte, tail, err := strconv.UnquoteChar(l.input[l.position:], '"')
+
+	if err != nil {
+		return l.escapeError()
+	}
+
+	// \x and octal escapes stand for a byte, not a code point.
+	if value < utf8.RuneSelf || !multibyte {
+		out.WriteByte(byte(value))
+	} else {
+		out.WriteRune(value)
+	}
+
+	for l.readPosition < len(l.input)-len(tail) {
+		l.readChar()
+	}
+
+	return nil
+}
+
+// escapeError reports the invalid escape whose backslash is under l.ch. The
+// token spans the backslash and the character after it, which is consumed
+// unless it ends the string.
+func (l *Lexer) escapeError() *token.Token {
+	start := l.currPosition()
+
+	if next := l.peekChar(); next != '"' && next != '\n' && next != 0 {
+		l.readChar()
+	}
+
+	end := l.currPosition()
+	end.Offset += utf8.RuneLen(l.ch)
+	end.Column++
+
+	return &token.Token{
+		Type:    token.ERROR,
+		Literal: "invalid escape sequence: " + l.input[start.Offset:end.Offset],
+		Start:   start,
+		End:     end,
+	}
+}
+
+// readBracedEscape decodes a \u{...} escape whose backslash is under l.ch.
+func (l *Lexer) readBracedEscape() (rune, bool) {
+	escape := l.input[l.position:]
+	end := strings.IndexByte(escape, '}')
+
+	if !strings.HasPrefix(escape, "\\u{") || end < 0 {
+		return 0, false
+	}
+
+	digits := escape[len("\\u{"):end]
+
+	if len(digits) == 0 || len(digits) > 6 {
+		return 0, false
+	}
+
+	code, err := strconv.ParseUint(digits, 16, 32)
+
+	if err != nil || !utf8.ValidRune(rune(code)) {
+		return 0, false
+	}
+
+	for l.position < len(l.input)-len(escape)+end {
+		l.readChar()
+	}
+
+	return rune(code), true
 }
 
-func (l *Lexer) readNumber() string {
+// readRawString reads a `raw string`. Its text is taken as written, with no
+// escapes and no interpolations, and may span lines; carriage returns are
+// dropped, as in Go, so the value does not depend on the file's line endings.
+// l.ch is left on the closing backtick.
+func (l *Lexer) readRawString() *token.Token {
+	var out strings.Builder
+
+	for {
+		l.readChar()
+
+		switch l.ch {
+		case '`':
+			return &token.Token{Type: token.STRING, Literal: out.String()}
+		case 0:
+			return &token.Token{Type: token.ERROR, Literal: "unterminated raw string literal"}
+		case '\r':
+		default:
+			out.WriteRune(l.ch)
+		}
+	}
+}
+
+// readLineComment reads a // or # comment up to, not including, the newline.
+func (l *Lexer) readLineComment() string {
 	position := l.position
-	for isDigit(l.ch) {
+
+	for l.ch != '\n' && l.ch != 0 {
 		l.readChar()
 	}
 
 	return l.input[position:l.position]
 }
 
+// readBlockComment reads a /* */ comment. Block comments nest, so commenting
+// out code that already holds a block comment works. An unterminated comment
+// yields an ERROR token.
+func (l *Lexer) readBlockComment() *token.Token {
+	position := l.position
+	depth := 0
+
+	for l.ch != 0 {
+		if l.ch == '/' && l.peekChar() == '*' {
+			depth++
+			l.readChar()
+		} else if l.ch == '*' && l.peekChar() == '/' {
+			depth--
+			l.readChar()
+		}
+
+		l.readChar()
+
+		if depth == 0 {
+			return &token.Token{Type: token.COMMENT, Literal: l.input[position:l.position]}
+		}
+	}
+
+	return &token.Token{Type: token.ERROR, Literal: "unterminated block comment"}
+}
+
+// readNumber reads an integer or a float literal. A float has a fraction part,
+// an exponent or both, as in 1.5, 2e10 and 1.5e-3; a dot or an 'e' that is not
+// followed by digits is left for the next token. An integer may be written in
+// hex, octal or binary with a 0x, 0o or 0b prefix, and any number may separate
+// its digits with underscores, as in 1_000_000.
+//
+// The digits are not validated here: a malformed literal such as 0b102 or 1__0
+// is read whole, so the parser reports it as a single invalid number.
+func (l *Lexer) readNumber() (token.TokenType, string) {
+	position := l.position
+	tokenType := token.TokenType(token.INT)
+
+	if l.ch == '0' && isBasePrefix(l.peekChar()) {
+		l.readChar()
+		l.readChar()
+
+		for isLetter(l.ch) || isDigit(l.ch) {
+			l.readChar()
+		}
+
+		return tokenType, l.input[position:l.position]
+	}
+
+	l.readDigits()
+
+	if l.ch == '.' && isDigit(l.peekChar()) {
+		tokenType = token.FLOAT
+		l.readChar()
+		l.readDigits()
+	}
+
+	if (l.ch == 'e' || l.ch == 'E') && l.isExponentStart() {
+		tokenType = token.FLOAT
+		l.readChar()
+		if l.ch == '+' || l.ch == '-' {
+			l.readChar()
+		}
+		l.readDigits()
+	}
+
+	return tokenType, l.input[position:l.position]
+}
+
+// readDigits reads decimal digits and the underscores between them.
+func (l *Lexer) readDigits() {
+	for isDigit(l.ch) || l.ch == '_' {
+		l.readChar()
+	}
+}
+
+// isExponentStart reports whether the 'e' at the current position starts an
+// exponent, i.e. it is followed by digits with an optional sign.
+func (l *Lexer) isExponentStart() bool {
+	next := l.peekChar()
+
+	if next == '+' || next == '-' {
+		if l.readPosition+1 >= len(l.input) {
+			return false
+		}
+		next = rune(l.input[l.readPosition+1])
+	}
+
+	return isDigit(next)
+}
+
 func (l *Lexer) skipWhitespace() {
 	for l.ch == ' ' || l.ch == '\t' || l.ch == '\n' || l.ch == '\r' {
 		l.readChar()
@@ -93,15 +367,66 @@ func (l *Lexer) makeTwoCharToken() *token.Token {
 		return &token.Token{Type: token.LT_EQ, Literal: tok}
 	case token.GT_EQ:
 		return &token.Token{Type: token.GT_EQ, Literal: tok}
+	case token.POWER:
+		return &token.Token{Type: token.POWER, Literal: tok}
+	case token.SHL:
+		return &token.Token{Type: token.SHL, Literal: tok}
+	case token.SHR:
+		return &token.Token{Type: token.SHR, Literal: tok}
+	case token.NULLISH:
+		return &token.Token{Type: token.NULLISH, Literal: tok}
+	case token.QUESTION_DOT:
+		return &token.Token{Type: token.QUESTION_DOT, Literal: tok}
+	case token.QUESTION_LCOL:
+		return &token.Token{Type: token.QUESTION_LCOL, Literal: tok}
+	case token.AND:
+		return &token.Token{Type: token.AND, Literal: tok}
+	case token.OR:
+		return &token.Token{Type: token.OR, Literal: tok}
+	case token.PLUS_ASSIGN:
+		return &token.Token{Type: token.PLUS_ASSIGN, Literal: tok}
+	case token.MINUS_ASSIGN:
+		return &token.Token{Type: token.MINUS_ASSIGN, Literal: tok}
+	case token.ASTERISK_ASSIGN:
+		return &token.Token{Type: token.ASTERISK_ASSIGN, Literal: tok}
+	case token.SLASH_ASSIGN:
+		return &token.Token{Type: token.SLASH_ASSIGN, Literal: tok}
 	}
 
 	return &token.Token{Type: token.ILLEGAL, Literal: string(tok)}
 }
 
+// NextToken returns the next significant token. Comments are skipped and kept
+// as trivia, see Comments.
 func (l *Lexer) NextToken() *token.Token {
-	tok := new(token.Token)
+	for {
+		l.skipWhitespace()
+
+		start := l.currPosition()
+		tok := l.readToken()
 
-	l.skipWhitespace()
+		// An invalid escape reports its own span rather than the string's.
+		if !tok.Start.IsValid() {
+			tok.Start = start
+			tok.End = l.currPosition()
+		}
+
+		if tok.Type != token.COMMENT {
+			return tok
+		}
+
+		l.comments = append(l.comments, tok)
+	}
+}
+
+// Comments returns the comments skipped so far, in source order, so tools such
+// as a formatter can put them back.
+func (l *Lexer) Comments() []*token.Token {
+	return l.comments
+}
+
+func (l *Lexer) readToken() *token.Token {
+	tok := new(token.Token)
 
 	switch l.ch {
 	case '=':
@@ -119,11 +444,27 @@ func (l *Lexer) NextToken() *token.Token {
 	case ',':
 		tok = newToken(token.COMMA, l.ch)
 	case '+':
-		tok = newToken(token.PLUS, l.ch)
+		if l.peekChar() == '=' {
+			tok = l.makeTwoCharToken()
+		} else {
+			tok = newToken(token.PLUS, l.ch)
+		}
 	case '{':
+		if len(l.templates) > 0 {
+			l.templates[len(l.templates)-1]++
+		}
 		tok = newToken(token.LBRACE, l.ch)
 	case '}':
-		tok = newToken(token.RBRACE, l.ch)
+		if len(l.templates) > 0 && l.templates[len(l.templates)-1] == 0 {
+			// The } closing an interpolation resumes the string around it.
+			l.templates = l.templates[:len(l.templates)-1]
+			tok = l.readStringPart(false)
+		} else {
+			if len(l.templates) > 0 {
+				l.templates[len(l.templates)-1]--
+			}
+			tok = newToken(token.RBRACE, l.ch)
+		}
 	case '[':
 		tok = newToken(token.LCOL, l.ch)
 	case ']':
@@ -131,7 +472,11 @@ func (l *Lexer) NextToken() *token.Token {
 	case ':':
 		tok = newToken(token.DOUBLECOL, l.ch)
 	case '-':
-		tok = newToken(token.MINUS, l.ch)
+		if l.peekChar() == '=' {
+			tok = l.makeTwoCharToken()
+		} else {
+			tok = newToken(token.MINUS, l.ch)
+		}
 	case '!':
 		if l.peekChar() == '=' {
 			tok = l.makeTwoCharToken()
@@ -139,65 +484,122 @@ func (l *Lexer) NextToken() *token.Token {
 			tok = newToken(token.BANG, l.ch)
 		}
 	case '/':
-		tok = newToken(token.SLASH, l.ch)
+		switch l.peekChar() {
+		case '/':
+			tok.Literal = l.readLineComment()
+			tok.Type = token.COMMENT
+			return tok
+		case '*':
+			return l.readBlockComment()
+		case '=':
+			tok = l.makeTwoCharToken()
+		default:
+			tok = newToken(token.SLASH, l.ch)
+		}
 	case '*':
-		tok = newToken(token.ASTERISK, l.ch)
+		if l.peekChar() == '=' || l.peekChar() == '*' {
+			tok = l.makeTwoCharToken()
+		} else {
+			tok = newToken(token.ASTERISK, l.ch)
+		}
 	case '<':
-		if l.peekChar() == '=' {
+		if l.peekChar() == '=' || l.peekChar() == '<' {
 			tok = l.makeTwoCharToken()
 		} else {
 			tok = newToken(token.LT, l.ch)
 		}
 	case '>':
-		if l.peekChar() == '=' {
+		if l.peekChar() == '=' || l.peekChar() == '>' {
 			tok = l.makeTwoCharToken()
 		} else {
 			tok = newToken(token.GT, l.ch)
 		}
 	case '#':
-		tok = newToken(token.HASH, l.ch)
+		tok.Literal = l.readLineComment()
+		tok.Type = token.COMMENT
+		return tok
+	case '&':
+		if l.peekChar() == '&' {
+			tok = l.makeTwoCharToken()
+		} else {
+			tok = newToken(token.AMPERSAND, l.ch)
+		}
+	case '|':
+		if l.peekChar() == '|' {
+			tok = l.makeTwoCharToken()
+		} else {
+			tok = newToken(token.PIPE, l.ch)
+		}
+	case '?':
+		switch l.peekChar() {
+		case '?', '.', '[':
+			tok = l.makeTwoCharToken()
+		default:
+			tok = newToken(token.ILLEGAL, l.ch)
+		}
+	case '.':
+		tok = newToken(token.DOT, l.ch)
+	case '^':
+		tok = newToken(token.CARET, l.ch)
+	case '~':
+		tok = newToken(token.TILDE, l.ch)
+	case '%':
+		tok = newToken(token.PERCENT, l.ch)
 	case '"':
-		tok.Literal = l.readString()
-		tok.Type = token.STRING
-
+		tok = l.readStringPart(true)
+	case '`':
+		tok = l.readRawString()
 	case 0:
+		if len(l.templates) > 0 {
+			l.templates = nil
+			tok.Type = token.ERROR
+			tok.Literal = "unterminated string interpolation"
+			return tok
+		}
 		tok.Literal = ""
 		tok.Type = token.EOF
+		return tok
 	default:
 		if isLetter(l.ch) {
 			tok.Literal = l.readIdentifier()
 			tok.Type = token.LookupIdent(tok.Literal)
 			return tok
 		} else if isDigit(l.ch) {
-			tok.Type = token.INT
-			tok.Literal = l.readNumber()
+			tok.Type, tok.Literal = l.readNumber()
 			return tok
 		} else {
 			tok = newToken(token.ILLEGAL, l.ch)
 		}
 	}
 
-	l.readChar()
+	// An unterminated string stops on the newline or the end of the input,
+	// which are not part of it.
+	if l.ch != 0 && l.ch != '\n' {
+		l.readChar()
+	}
 	return tok
 }
 
-func newToken(tokenType token.TokenType, ch byte) *token.Token {
+func newToken(tokenType token.TokenType, ch rune) *token.Token {
 	return &token.Token{
 		Type:    tokenType,
 		Literal: string(ch),
 	}
 }
 
-func isLetter(ch byte) bool {
-	return 'a' <= ch && ch <= 'z' ||
-		'A' <= ch && ch <= 'Z' ||
-		ch == '_'
+func isLetter(ch rune) bool {
+	return unicode.IsLetter(ch) || ch == '_'
 }
 
-func isDigit(ch byte) bool {
-	return '0' <= ch && ch <= '9'
+func isBasePrefix(ch rune) bool {
+	switch ch {
+	case 'x', 'X', 'o', 'O', 'b', 'B':
+		return true
+	}
+
+	return false
 }
 
-func isScapeChar(ch byte) bool {
-	return ch == '\\' || ch == 'n' || ch == 't' || ch == '"'
+func isDigit(ch rune) bool {
+	return '0' <= ch && ch <= '9'
 }
@@ -47,7 +47,7 @@ func TestNextTokenWithFunctionInput(t *testing.T) {
 		x + y;
 	};
 	let result = add(five,ten);
-	!-/*5#;
+	!-/ *5;
 	5 < 10 > 5;
 
 	if (5 < 10) {
@@ -109,7 +109,6 @@ func TestNextTokenWithFunctionInput(t *testing.T) {
 		{token.SLASH, "/"},
 		{token.ASTERISK, "*"},
 		{token.INT, "5"},
-		{token.HASH, "#"},
 		{token.SEMICOLON, ";"},
 		{token.INT, "5"},
 		{token.LT, "<"},
@@ -173,3 +172,643 @@ func TestNextTokenWithFunctionInput(t *testing.T) {
 		}
 	}
 }
+
+func TestNextTokenPositions(t *testing.T) {
+	input := `let x = 5;
+  x + "ab";
+if (x >= 10) {
+	x
+}`
+
+	tests := []struct {
+		expectedType  token.TokenType
+		expectedStart token.Position
+		expectedEnd   token.Position
+	}{
+		{token.LET, token.Position{Offset: 0, Line: 1, Column: 1}, token.Position{Offset: 3, Line: 1, Column: 4}},
+		{token.IDENT, token.Position{Offset: 4, Line: 1, Column: 5}, token.Position{Offset: 5, Line: 1, Column: 6}},
+		{token.ASSIGN, token.Position{Offset: 6, Line: 1, Column: 7}, token.Position{Offset: 7, Line: 1, Column: 8}},
+		{token.INT, token.Position{Offset: 8, Line: 1, Column: 9}, token.Position{Offset: 9, Line: 1, Column: 10}},
+		{token.SEMICOLON, token.Position{Offset: 9, Line: 1, Column: 10}, token.Position{Offset: 10, Line: 1, Column: 11}},
+		{token.IDENT, token.Position{Offset: 13, Line: 2, Column: 3}, token.Position{Offset: 14, Line: 2, Column: 4}},
+		{token.PLUS, token.Position{Offset: 15, Line: 2, Column: 5}, token.Position{Offset: 16, Line: 2, Column: 6}},
+		{token.STRING, token.Position{Offset: 17, Line: 2, Column: 7}, token.Position{Offset: 21, Line: 2, Column: 11}},
+		{token.SEMICOLON, token.Position{Offset: 21, Line: 2, Column: 11}, token.Position{Offset: 22, Line: 2, Column: 12}},
+		{token.IF, token.Position{Offset: 23, Line: 3, Column: 1}, token.Position{Offset: 25, Line: 3, Column: 3}},
+		{token.LPAREN, token.Position{Offset: 26, Line: 3, Column: 4}, token.Position{Offset: 27, Line: 3, Column: 5}},
+		{token.IDENT, token.Position{Offset: 27, Line: 3, Column: 5}, token.Position{Offset: 28, Line: 3, Column: 6}},
+		{token.GT_EQ, token.Position{Offset: 29, Line: 3, Column: 7}, token.Position{Offset: 31, Line: 3, Column: 9}},
+		{token.INT, token.Position{Offset: 32, Line: 3, Column: 10}, token.Position{Offset: 34, Line: 3, Column: 12}},
+		{token.RPAREN, token.Position{Offset: 34, Line: 3, Column: 12}, token.Position{Offset: 35, Line: 3, Column: 13}},
+		{token.LBRACE, token.Position{Offset: 36, Line: 3, Column: 14}, token.Position{Offset: 37, Line: 3, Column: 15}},
+		{token.IDENT, token.Position{Offset: 39, Line: 4, Column: 2}, token.Position{Offset: 40, Line: 4, Column: 3}},
+		{token.RBRACE, token.Position{Offset: 41, Line: 5, Column: 1}, token.Position{Offset: 42, Line: 5, Column: 2}},
+		{token.EOF, token.Position{Offset: 42, Line: 5, Column: 2}, token.Position{Offset: 42, Line: 5, Column: 2}},
+	}
+
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got =%q", i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Start != tt.expectedStart {
+			t.Errorf("tests[%d] - start wrong. expected=%+v, got=%+v", i, tt.expectedStart, tok.Start)
+		}
+
+		if tok.End != tt.expectedEnd {
+			t.Errorf("tests[%d] - end wrong. expected=%+v, got=%+v", i, tt.expectedEnd, tok.End)
+		}
+	}
+}
+
+func TestNextTokenPositionsWithFilename(t *testing.T) {
+	l := NewWithFilename("script.mk", "\n\n  foo")
+
+	tok := l.NextToken()
+
+	if tok.Start.String() != "script.mk:3:3" {
+		t.Errorf("tok.Start.String() wrong. expected=%q, got=%q", "script.mk:3:3", tok.Start.String())
+	}
+}
+
+func TestNextTokenWithNumbers(t *testing.T) {
+	input := `5 3.14 0.5 1e3 1.5e-3 2E+10 7.0; 1.x 2e 3e+ [1].y`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.INT, "5"},
+		{token.FLOAT, "3.14"},
+		{token.FLOAT, "0.5"},
+		{token.FLOAT, "1e3"},
+		{token.FLOAT, "1.5e-3"},
+		{token.FLOAT, "2E+10"},
+		{token.FLOAT, "7.0"},
+		{token.SEMICOLON, ";"},
+		{token.INT, "1"},
+		{token.DOT, "."},
+		{token.IDENT, "x"},
+		{token.INT, "2"},
+		{token.IDENT, "e"},
+		{token.INT, "3"},
+		{token.IDENT, "e"},
+		{token.PLUS, "+"},
+		{token.LCOL, "["},
+		{token.INT, "1"},
+		{token.RCOL, "]"},
+		{token.DOT, "."},
+		{token.IDENT, "y"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got =%q", i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got =%q", i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNextTokenWithIntegerBases(t *testing.T) {
+	input := `0xFF 0XdEaD_bEeF 0o17 0b1010 1_000_000 1_000.5 0 0b102 0x 1__0 7_ x`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.INT, "0xFF"},
+		{token.INT, "0XdEaD_bEeF"},
+		{token.INT, "0o17"},
+		{token.INT, "0b1010"},
+		{token.INT, "1_000_000"},
+		{token.FLOAT, "1_000.5"},
+		{token.INT, "0"},
+		{token.INT, "0b102"},
+		{token.INT, "0x"},
+		{token.INT, "1__0"},
+		{token.INT, "7_"},
+		{token.IDENT, "x"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got =%q", i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got =%q", i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNextTokenSkipsComments(t *testing.T) {
+	input := `// leading comment
+let x = 10; # hash comment
+/* block
+   comment */ x / 2 /* nested /* inner */ still comment */ ;
+#
+x //`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.LET, "let"},
+		{token.IDENT, "x"},
+		{token.ASSIGN, "="},
+		{token.INT, "10"},
+		{token.SEMICOLON, ";"},
+		{token.IDENT, "x"},
+		{token.SLASH, "/"},
+		{token.INT, "2"},
+		{token.SEMICOLON, ";"},
+		{token.IDENT, "x"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got =%q", i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got =%q", i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+
+	comments := []struct {
+		expectedLiteral string
+		expectedStart   string
+		expectedEnd     string
+	}{
+		{"// leading comment", "1:1", "1:19"},
+		{"# hash comment", "2:13", "2:27"},
+		{"/* block\n   comment */", "3:1", "4:14"},
+		{"/* nested /* inner */ still comment */", "4:21", "4:59"},
+		{"#", "5:1", "5:2"},
+		{"//", "6:3", "6:5"},
+	}
+
+	if len(l.Comments()) != len(comments) {
+		t.Fatalf("wrong number of comments, expected=%d, got=%d", len(comments), len(l.Comments()))
+	}
+
+	for i, tt := range comments {
+		comment := l.Comments()[i]
+
+		if comment.Type != token.COMMENT {
+			t.Errorf("comments[%d] - tokentype wrong. expected=%q, got=%q", i, token.COMMENT, comment.Type)
+		}
+
+		if comment.Literal != tt.expectedLiteral {
+			t.Errorf("comments[%d] - literal wrong. expected=%q, got=%q", i, tt.expectedLiteral, comment.Literal)
+		}
+
+		if comment.Start.String() != tt.expectedStart || comment.End.String() != tt.expectedEnd {
+			t.Errorf("comments[%d] - span wrong. expected=%s-%s, got=%s-%s", i, tt.expectedStart, tt.expectedEnd, comment.Start, comment.End)
+		}
+	}
+}
+
+func TestNextTokenUnterminatedBlockComment(t *testing.T) {
+	l := New("1 /* open /* nested */ never closed")
+
+	tok := l.NextToken()
+
+	if tok.Type != token.INT {
+		t.Fatalf("tokentype wrong. expected=%q, got=%q", token.INT, tok.Type)
+	}
+
+	tok = l.NextToken()
+
+	if tok.Type != token.ERROR {
+		t.Fatalf("tokentype wrong. expected=%q, got=%q", token.ERROR, tok.Type)
+	}
+
+	if tok.Literal != "unterminated block comment" {
+		t.Fatalf("literal wrong, got=%q", tok.Literal)
+	}
+
+	if tok.Start.Column != 3 || tok.End.Column != 36 {
+		t.Fatalf("span wrong, got=%d-%d", tok.Start.Column, tok.End.Column)
+	}
+
+	if tok = l.NextToken(); tok.Type != token.EOF {
+		t.Fatalf("tokentype wrong. expected=%q, got=%q", token.EOF, tok.Type)
+	}
+}
+
+func TestNextTokenWithLoopKeywords(t *testing.T) {
+	input := `while (x) { break; } for (y in ys) { continue; }`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.WHILE, "while"},
+		{token.LPAREN, "("},
+		{token.IDENT, "x"},
+		{token.RPAREN, ")"},
+		{token.LBRACE, "{"},
+		{token.BREAK, "break"},
+		{token.SEMICOLON, ";"},
+		{token.RBRACE, "}"},
+		{token.FOR, "for"},
+		{token.LPAREN, "("},
+		{token.IDENT, "y"},
+		{token.IN, "in"},
+		{token.IDENT, "ys"},
+		{token.RPAREN, ")"},
+		{token.LBRACE, "{"},
+		{token.CONTINUE, "continue"},
+		{token.SEMICOLON, ";"},
+		{token.RBRACE, "}"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got =%q", i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got =%q", i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNextTokenWithAssignmentOperators(t *testing.T) {
+	input := `x = 1; x += 2; x -= 3; x *= 4; x /= 5; x == y; x-=-1`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.IDENT, "x"},
+		{token.ASSIGN, "="},
+		{token.INT, "1"},
+		{token.SEMICOLON, ";"},
+		{token.IDENT, "x"},
+		{token.PLUS_ASSIGN, "+="},
+		{token.INT, "2"},
+		{token.SEMICOLON, ";"},
+		{token.IDENT, "x"},
+		{token.MINUS_ASSIGN, "-="},
+		{token.INT, "3"},
+		{token.SEMICOLON, ";"},
+		{token.IDENT, "x"},
+		{token.ASTERISK_ASSIGN, "*="},
+		{token.INT, "4"},
+		{token.SEMICOLON, ";"},
+		{token.IDENT, "x"},
+		{token.SLASH_ASSIGN, "/="},
+		{token.INT, "5"},
+		{token.SEMICOLON, ";"},
+		{token.IDENT, "x"},
+		{token.EQ, "=="},
+		{token.IDENT, "y"},
+		{token.SEMICOLON, ";"},
+		{token.IDENT, "x"},
+		{token.MINUS_ASSIGN, "-="},
+		{token.MINUS, "-"},
+		{token.INT, "1"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got =%q", i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got =%q", i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNextTokenWithLogicalOperators(t *testing.T) {
+	input := `a && b || !c`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.IDENT, "a"},
+		{token.AND, "&&"},
+		{token.IDENT, "b"},
+		{token.OR, "||"},
+		{token.BANG, "!"},
+		{token.IDENT, "c"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got =%q", i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got =%q", i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNextTokenWithArithmeticAndBitwiseOperators(t *testing.T) {
+	input := `a % b ** c & d | e ^ ~f << 2 >> 1 *= g <<= h`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.IDENT, "a"},
+		{token.PERCENT, "%"},
+		{token.IDENT, "b"},
+		{token.POWER, "**"},
+		{token.IDENT, "c"},
+		{token.AMPERSAND, "&"},
+		{token.IDENT, "d"},
+		{token.PIPE, "|"},
+		{token.IDENT, "e"},
+		{token.CARET, "^"},
+		{token.TILDE, "~"},
+		{token.IDENT, "f"},
+		{token.SHL, "<<"},
+		{token.INT, "2"},
+		{token.SHR, ">>"},
+		{token.INT, "1"},
+		{token.ASTERISK_ASSIGN, "*="},
+		{token.IDENT, "g"},
+		{token.SHL, "<<"},
+		{token.ASSIGN, "="},
+		{token.IDENT, "h"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got =%q", i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got =%q", i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNextTokenWithNullAndOptionalAccess(t *testing.T) {
+	input := `null ?? a?.b?[0] ? c`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.NULL, "null"},
+		{token.NULLISH, "??"},
+		{token.IDENT, "a"},
+		{token.QUESTION_DOT, "?."},
+		{token.IDENT, "b"},
+		{token.QUESTION_LCOL, "?["},
+		{token.INT, "0"},
+		{token.RCOL, "]"},
+		{token.ILLEGAL, "?"},
+		{token.IDENT, "c"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got =%q", i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got =%q", i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNextTokenWithUnicode(t *testing.T) {
+	input := `let olá = "ação\u{1F600}\t\$";
+变量 + _ñ;`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.LET, "let"},
+		{token.IDENT, "olá"},
+		{token.ASSIGN, "="},
+		{token.STRING, "ação😀\t$"},
+		{token.SEMICOLON, ";"},
+		{token.IDENT, "变量"},
+		{token.PLUS, "+"},
+		{token.IDENT, "_ñ"},
+		{token.SEMICOLON, ";"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got =%q", i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got =%q", i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNextTokenPositionsWithUnicode(t *testing.T) {
+	input := `let é = "日本";
+é`
+
+	tests := []struct {
+		expectedType  token.TokenType
+		expectedStart token.Position
+		expectedEnd   token.Position
+	}{
+		{token.LET, token.Position{Offset: 0, Line: 1, Column: 1}, token.Position{Offset: 3, Line: 1, Column: 4}},
+		{token.IDENT, token.Position{Offset: 4, Line: 1, Column: 5}, token.Position{Offset: 6, Line: 1, Column: 6}},
+		{token.ASSIGN, token.Position{Offset: 7, Line: 1, Column: 7}, token.Position{Offset: 8, Line: 1, Column: 8}},
+		{token.STRING, token.Position{Offset: 9, Line: 1, Column: 9}, token.Position{Offset: 17, Line: 1, Column: 13}},
+		{token.SEMICOLON, token.Position{Offset: 17, Line: 1, Column: 13}, token.Position{Offset: 18, Line: 1, Column: 14}},
+		{token.IDENT, token.Position{Offset: 19, Line: 2, Column: 1}, token.Position{Offset: 21, Line: 2, Column: 2}},
+		{token.EOF, token.Position{Offset: 21, Line: 2, Column: 2}, token.Position{Offset: 21, Line: 2, Column: 2}},
+	}
+
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got =%q", i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Start != tt.expectedStart {
+			t.Errorf("tests[%d] - start wrong. expected=%+v, got=%+v", i, tt.expectedStart, tok.Start)
+		}
+
+		if tok.End != tt.expectedEnd {
+			t.Errorf("tests[%d] - end wrong. expected=%+v, got=%+v", i, tt.expectedEnd, tok.End)
+		}
+	}
+}
+
+func TestNextTokenWithInterpolation(t *testing.T) {
+	input := `"Hi ${name}, ${ {"a": "${x}"}["a"] }!" "${}"`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.TEMPLATE_HEAD, "Hi "},
+		{token.IDENT, "name"},
+		{token.TEMPLATE_MIDDLE, ", "},
+		{token.LBRACE, "{"},
+		{token.STRING, "a"},
+		{token.DOUBLECOL, ":"},
+		{token.TEMPLATE_HEAD, ""},
+		{token.IDENT, "x"},
+		{token.TEMPLATE_TAIL, ""},
+		{token.RBRACE, "}"},
+		{token.LCOL, "["},
+		{token.STRING, "a"},
+		{token.RCOL, "]"},
+		{token.TEMPLATE_TAIL, "!"},
+		{token.TEMPLATE_HEAD, ""},
+		{token.TEMPLATE_TAIL, ""},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got =%q", i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got =%q", i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNextTokenWithRawStrings(t *testing.T) {
+	input := "`a\\n${b}\"` `two\r\nlines` ``"
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.STRING, `a\n${b}"`},
+		{token.STRING, "two\nlines"},
+		{token.STRING, ""},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got =%q", i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got =%q", i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNextTokenStringErrors(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedLiteral string
+		expectedStart   int
+		expectedEnd     int
+		next            token.TokenType
+	}{
+		{`"open`, "unterminated string literal", 1, 6, token.EOF},
+		{"\"open\n1", "unterminated string literal", 1, 6, token.INT},
+		{`"a\qb" 1`, `invalid escape sequence: \q`, 3, 5, token.INT},
+		{`"\u{110000}" 1`, `invalid escape sequence: \u`, 2, 4, token.INT},
+		{`"\xZZ" 1`, `invalid escape sequence: \x`, 2, 4, token.INT},
+		{`"${a}\z" 1`, `invalid escape sequence: \z`, 6, 8, token.INT},
+		{`"${a`, "unterminated string interpolation", 5, 5, token.EOF},
+		{"`open\nraw", "unterminated raw string literal", 1, 4, token.EOF},
+	}
+
+	for _, tt := range tests {
+		l := New(tt.input)
+		tok := l.NextToken()
+
+		for tok.Type != token.ERROR && tok.Type != token.EOF {
+			tok = l.NextToken()
+		}
+
+		if tok.Type != token.ERROR {
+			t.Errorf("%q: expected an ERROR token, got=%q", tt.input, tok.Type)
+			continue
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Errorf("%q: literal wrong. expected=%q, got=%q", tt.input, tt.expectedLiteral, tok.Literal)
+		}
+
+		if tok.Start.Column != tt.expectedStart || tok.End.Column != tt.expectedEnd {
+			t.Errorf("%q: span wrong. expected=%d-%d, got=%d-%d", tt.input, tt.expectedStart, tt.expectedEnd, tok.Start.Column, tok.End.Column)
+		}
+
+		if next := l.NextToken(); next.Type != tt.next {
+			t.Errorf("%q: next token wrong. expected=%q, got=%q", tt.input, tt.next, next.Type)
+		}
+	}
+}
@@ -2,13 +2,23 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/user"
 
+	"github.com/rodmedeiross/monkey-interpreter/diagnostics"
+	"github.com/rodmedeiross/monkey-interpreter/evaluator"
+	"github.com/rodmedeiross/monkey-interpreter/lexer"
+	"github.com/rodmedeiross/monkey-interpreter/object"
+	"github.com/rodmedeiross/monkey-interpreter/parser"
 	"github.com/rodmedeiross/monkey-interpreter/repl"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		os.Exit(runFile(os.Args[1], os.Stderr))
+	}
+
 	user, err := user.Current()
 
 	if err != nil {
@@ -19,3 +29,35 @@ func main() {
 	fmt.Printf("Feel free to type in commands \n")
 	repl.Start(os.Stdin, os.Stdout)
 }
+
+// runFile evaluates the script at filename, rendering any parser or runtime
+// error to errOut, and returns the process exit code.
+func runFile(filename string, errOut io.Writer) int {
+	source, err := os.ReadFile(filename)
+
+	if err != nil {
+		fmt.Fprintln(errOut, err)
+		return 1
+	}
+
+	l := lexer.NewWithFilename(filename, string(source))
+	p := parser.New(l)
+
+	program := p.ParserProgram()
+
+	if len(p.Errors()) != 0 {
+		for _, err := range p.Errors() {
+			diagnostics.Render(errOut, string(source), err.Diagnostic())
+		}
+		return 1
+	}
+
+	evaluated := evaluator.Eval(program, object.NewEnvironment())
+
+	if err, ok := evaluated.(*object.Error); ok {
+		diagnostics.Render(errOut, string(source), err.Diagnostic())
+		return 1
+	}
+
+	return 0
+}
@@ -28,6 +28,23 @@ func (e *Environment) Get(key string) (Object, bool) {
 	return obj, ok
 }
 
+// Assign rebinds key in the nearest environment that declares it, and reports
+// false when no environment in the chain does.
+func (e *Environment) Assign(key string, obj Object) (Object, bool) {
+	_, ok := e.store[key]
+
+	if ok {
+		e.store[key] = obj
+		return obj, ok
+	}
+
+	if e.outer != nil {
+		return e.outer.Assign(key, obj)
+	}
+
+	return nil, ok
+}
+
 func (e *Environment) Set(key string, obj Object) Object {
 	e.store[key] = obj
 	return obj
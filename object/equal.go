@@ -0,0 +1,130 @@
+package object
+
+import (
+	"math"
+	"math/big"
+)
+
+// Equal reports whether a and b are equal under the language's == operator.
+// Numbers compare by value across integers and floats; strings, booleans and
+// null by value; arrays element by element and hashes by their pairs,
+// regardless of insertion order. Anything else, functions included, is only
+// equal to itself.
+//
+// Containers may hold themselves. A pair of containers met again while it is
+// still being compared is taken as equal, so the comparison terminates and two
+// arrays that each hold only themselves are equal.
+func Equal(a, b Object) bool {
+	return equal(a, b, map[[2]Object]bool{})
+}
+
+// equal is Equal; comparing holds the pairs of containers being compared.
+func equal(a, b Object, comparing map[[2]Object]bool) bool {
+	if a == b {
+		return true
+	}
+
+	switch a := a.(type) {
+	case *Integer:
+		switch b := b.(type) {
+		case *Integer:
+			return a.Value == b.Value
+		case *BigInt:
+			return b.Value.IsInt64() && b.Value.Int64() == a.Value
+		case *Float:
+			return float64(a.Value) == b.Value
+		}
+	case *BigInt:
+		switch b := b.(type) {
+		case *Integer:
+			return a.Value.IsInt64() && a.Value.Int64() == b.Value
+		case *BigInt:
+			return a.Value.Cmp(b.Value) == 0
+		case *Float:
+			return bigIntEqualsFloat(a.Value, b.Value)
+		}
+	case *Float:
+		switch b := b.(type) {
+		case *Integer:
+			return a.Value == float64(b.Value)
+		case *BigInt:
+			return bigIntEqualsFloat(b.Value, a.Value)
+		case *Float:
+			return a.Value == b.Value
+		}
+	case *Boolean:
+		b, ok := b.(*Boolean)
+		return ok && a.Value == b.Value
+	case *Null:
+		_, ok := b.(*Null)
+		return ok
+	case *String:
+		b, ok := b.(*String)
+		return ok && a.Value == b.Value
+	case *Array:
+		b, ok := b.(*Array)
+		return ok && arraysEqual(a, b, comparing)
+	case *HashObject:
+		b, ok := b.(*HashObject)
+		return ok && hashesEqual(a, b, comparing)
+	}
+
+	return false
+}
+
+// bigIntEqualsFloat compares i and f exactly, without rounding i to a float.
+func bigIntEqualsFloat(i *big.Int, f float64) bool {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return false
+	}
+
+	return new(big.Float).SetInt(i).Cmp(big.NewFloat(f)) == 0
+}
+
+func arraysEqual(a, b *Array, comparing map[[2]Object]bool) bool {
+	if len(a.Elements) != len(b.Elements) {
+		return false
+	}
+
+	pair := [2]Object{a, b}
+
+	if comparing[pair] {
+		return true
+	}
+
+	comparing[pair] = true
+	defer delete(comparing, pair)
+
+	for i := range a.Elements {
+		if !equal(a.Elements[i], b.Elements[i], comparing) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func hashesEqual(a, b *HashObject, comparing map[[2]Object]bool) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+
+	hashes := [2]Object{a, b}
+
+	if comparing[hashes] {
+		return true
+	}
+
+	comparing[hashes] = true
+	defer delete(comparing, hashes)
+
+	for _, pair := range a.Pairs() {
+		value, ok := b.Get(pair.Key.(Hashable))
+
+		if !ok || !equal(pair.Value, value, comparing) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -1,10 +1,16 @@
 package object
 
-import "hash/fnv"
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/big"
+)
 
 var (
-	true  = HashSet{ObjectType: BOOLEAN_OBJ, Value: uint64(1)}
-	false = HashSet{ObjectType: BOOLEAN_OBJ, Value: uint64(0)}
+	trueKey  = HashSet{ObjectType: BOOLEAN_OBJ, Value: uint64(1)}
+	falseKey = HashSet{ObjectType: BOOLEAN_OBJ, Value: uint64(0)}
 )
 
 type HashSet struct {
@@ -13,14 +19,15 @@ type HashSet struct {
 }
 
 type Hashable interface {
+	Object
 	Hash() HashSet
 }
 
 func (b *Boolean) Hash() HashSet {
 	if b.Value {
-		return true
+		return trueKey
 	} else {
-		return false
+		return falseKey
 	}
 }
 
@@ -28,9 +35,146 @@ func (i *Integer) Hash() HashSet {
 	return HashSet{ObjectType: INTEGER_OBJ, Value: uint64(i.Value)}
 }
 
+// Hash gives a BigInt the same key as the equal Integer or Float, if there is
+// one. Other values are keyed by a hash of their digits.
+func (i *BigInt) Hash() HashSet {
+	if i.Value.IsInt64() {
+		return (&Integer{Value: i.Value.Int64()}).Hash()
+	}
+
+	if f, accuracy := new(big.Float).SetInt(i.Value).Float64(); accuracy == big.Exact {
+		return (&Float{Value: f}).Hash()
+	}
+
+	hash := fnv.New64a()
+	hash.Write([]byte(i.Value.String()))
+
+	return HashSet{ObjectType: INTEGER_OBJ, Value: hash.Sum64()}
+}
+
+// Hash gives a float with an integral value the same key as the equal Integer,
+// since 1 == 1.0, and -0.0 the same key as 0. Other floats are keyed by their
+// IEEE 754 bits, with every NaN sharing a single key.
+func (f *Float) Hash() HashSet {
+	if f.Value == math.Trunc(f.Value) && f.Value >= math.MinInt64 && f.Value < math.MaxInt64 {
+		return HashSet{ObjectType: INTEGER_OBJ, Value: uint64(int64(f.Value))}
+	}
+
+	if math.IsNaN(f.Value) {
+		return HashSet{ObjectType: FLOAT_OBJ, Value: math.Float64bits(math.NaN())}
+	}
+
+	return HashSet{ObjectType: FLOAT_OBJ, Value: math.Float64bits(f.Value)}
+}
+
 func (i *String) Hash() HashSet {
 	hash := fnv.New64a()
 	hash.Write([]byte(i.Value))
 
-	return HashSet{ObjectType: INTEGER_OBJ, Value: hash.Sum64()}
+	return HashSet{ObjectType: STRING_OBJ, Value: hash.Sum64()}
+}
+
+// Hash combines the hashes of the elements, so an array can key a hash as a
+// tuple. Every element must be Hashable, at any depth; callers check with
+// UnhashableElement first.
+func (arr *Array) Hash() HashSet {
+	hash := fnv.New64a()
+	buf := make([]byte, 8)
+
+	for _, el := range arr.Elements {
+		key := el.(Hashable).Hash()
+
+		hash.Write([]byte(key.ObjectType))
+		binary.LittleEndian.PutUint64(buf, key.Value)
+		hash.Write(buf)
+	}
+
+	return HashSet{ObjectType: ARRAY_OBJ, Value: hash.Sum64()}
+}
+
+// UnhashableElement finds the first element of arr, searching nested arrays,
+// that cannot be part of a hash key: an element that is not Hashable, or an
+// array that holds itself and so has no finite hash. It returns the element and
+// its path of indexes, such as "[1][0]", or nil when arr can be used as a key.
+func (arr *Array) UnhashableElement() (Object, string) {
+	return arr.unhashableElement(map[*Array]bool{})
+}
+
+// unhashableElement is UnhashableElement; enclosing holds the arrays around arr.
+func (arr *Array) unhashableElement(enclosing map[*Array]bool) (Object, string) {
+	enclosing[arr] = true
+	defer delete(enclosing, arr)
+
+	for i, el := range arr.Elements {
+		if inner, ok := el.(*Array); ok {
+			if enclosing[inner] {
+				return inner, fmt.Sprintf("[%d]", i)
+			}
+
+			if bad, path := inner.unhashableElement(enclosing); bad != nil {
+				return bad, fmt.Sprintf("[%d]%s", i, path)
+			}
+			continue
+		}
+
+		if _, ok := el.(Hashable); !ok {
+			return el, fmt.Sprintf("[%d]", i)
+		}
+	}
+
+	return nil, ""
+}
+
+// frozenKey copies array keys, at any depth, so that mutating the array a key
+// was made from cannot change the key inside a hash.
+func frozenKey(key Hashable) Hashable {
+	arr, ok := key.(*Array)
+
+	if !ok {
+		return key
+	}
+
+	elements := make([]Object, len(arr.Elements))
+
+	for i, el := range arr.Elements {
+		elements[i] = frozenKey(el.(Hashable))
+	}
+
+	return &Array{Elements: elements}
+}
+
+// keysEqual compares hash keys by value, so that keys whose hashes collide are
+// still told apart. It is Equal, except that all NaNs are the same key,
+// matching Float.Hash, also inside array keys.
+func keysEqual(a, b Hashable) bool {
+	if isNumber(a) && isNumber(b) {
+		return Equal(a, b) || isNaN(a) && isNaN(b)
+	}
+
+	if a, ok := a.(*Array); ok {
+		b, ok := b.(*Array)
+
+		if !ok || len(a.Elements) != len(b.Elements) {
+			return false
+		}
+
+		for i := range a.Elements {
+			if !keysEqual(a.Elements[i].(Hashable), b.Elements[i].(Hashable)) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return Equal(a, b)
+}
+
+func isNumber(obj Object) bool {
+	return obj.Type() == INTEGER_OBJ || obj.Type() == BIGINT_OBJ || obj.Type() == FLOAT_OBJ
+}
+
+func isNaN(obj Object) bool {
+	f, ok := obj.(*Float)
+	return ok && math.IsNaN(f.Value)
 }
@@ -3,24 +3,33 @@ package object
 import (
 	"bytes"
 	"fmt"
+	"math/big"
+	"strconv"
 	"strings"
 
 	"github.com/rodmedeiross/monkey-interpreter/ast"
+	"github.com/rodmedeiross/monkey-interpreter/diagnostics"
+	"github.com/rodmedeiross/monkey-interpreter/token"
 )
 
 type ObjectType string
 
 const (
 	INTEGER_OBJ  = "INTEGER"
+	FLOAT_OBJ    = "FLOAT"
 	BOOLEAN_OBJ  = "BOOLEAN"
 	NULL_OBJ     = "NULL"
 	RETURN_OBJ   = "RETURN"
+	BREAK_OBJ    = "BREAK"
+	CONTINUE_OBJ = "CONTINUE"
 	ERROR_OBJ    = "ERROR"
 	FUNCTION_OBJ = "FUNCTION"
 	STRING_OBJ   = "STRING_OBJ"
 	BUILT_IN_OBJ = "BUILT_IN"
 	ARRAY_OBJ    = "ARRAY_OBJ"
 	HASH         = "HASH"
+
+	BIGINT_OBJ = "BIGINT"
 )
 
 type Object interface {
@@ -35,6 +44,33 @@ type Integer struct {
 func (i *Integer) Type() ObjectType { return INTEGER_OBJ }
 func (i *Integer) Inspect() string  { return fmt.Sprintf("%d", i.Value) }
 
+// BigInt is an integer outside the range of int64. Integer arithmetic promotes
+// its result to a BigInt on overflow and demotes it back to an Integer as soon
+// as it fits, so a BigInt never holds a value an Integer could.
+type BigInt struct {
+	Value *big.Int
+}
+
+func (i *BigInt) Type() ObjectType { return BIGINT_OBJ }
+func (i *BigInt) Inspect() string  { return i.Value.String() }
+
+type Float struct {
+	Value float64
+}
+
+func (f *Float) Type() ObjectType { return FLOAT_OBJ }
+
+// Inspect always shows a float as such, so 2.0 is not mistaken for an integer.
+func (f *Float) Inspect() string {
+	s := strconv.FormatFloat(f.Value, 'g', -1, 64)
+
+	if strings.ContainsAny(s, ".eIN") {
+		return s
+	}
+
+	return s + ".0"
+}
+
 type Boolean struct {
 	Value bool
 }
@@ -54,20 +90,76 @@ type Return struct {
 func (r *Return) Type() ObjectType { return RETURN_OBJ }
 func (r *Return) Inspect() string  { return r.Value.Inspect() }
 
+// Break and Continue are the signals a loop body hands back to the loop.
+type Break struct{}
+
+func (b *Break) Type() ObjectType { return BREAK_OBJ }
+func (b *Break) Inspect() string  { return "break" }
+
+type Continue struct{}
+
+func (c *Continue) Type() ObjectType { return CONTINUE_OBJ }
+func (c *Continue) Inspect() string  { return "continue" }
+
+// StackFrame is a function call an error unwound through. Pos is the call site.
+type StackFrame struct {
+	Function string
+	Pos      token.Position
+}
+
+func (sf StackFrame) String() string {
+	return fmt.Sprintf("in %s, called at %s", sf.Function, sf.Pos)
+}
+
 type Error struct {
 	Message string
+	Pos     token.Position
+	End     token.Position
+	Stack   []StackFrame // Innermost call first
 }
 
-func (e *Error) Type() ObjectType { return RETURN_OBJ }
-func (e *Error) Inspect() string  { return e.Message }
+func (e *Error) Type() ObjectType { return ERROR_OBJ }
+func (e *Error) Inspect() string {
+	if e.Pos.IsValid() {
+		return e.Pos.String() + ": " + e.Message
+	}
+
+	return e.Message
+}
+
+func (e *Error) Diagnostic() *diagnostics.Diagnostic {
+	notes := []string{}
+
+	for _, frame := range e.Stack {
+		notes = append(notes, frame.String())
+	}
+
+	return &diagnostics.Diagnostic{
+		Severity: diagnostics.ERROR,
+		Span:     diagnostics.Span{Start: e.Pos, End: e.End},
+		Message:  e.Message,
+		Notes:    notes,
+	}
+}
 
 type Function struct {
+	Name       string
 	Parameters []*ast.Identifier
 	Body       *ast.BlockStatement
 	Env        *Environment
 }
 
 func (f *Function) Type() ObjectType { return FUNCTION_OBJ }
+
+// FrameName is the name the function is reported with in stack traces.
+func (f *Function) FrameName() string {
+	if f.Name == "" {
+		return "<anonymous>"
+	}
+
+	return f.Name
+}
+
 func (f *Function) Inspect() string {
 	var out bytes.Buffer
 
@@ -107,13 +199,24 @@ type Array struct {
 }
 
 func (arr *Array) Type() ObjectType { return ARRAY_OBJ }
-func (arr *Array) Inspect() string {
+func (arr *Array) Inspect() string  { return arr.inspect(map[Object]bool{}) }
+
+// inspect renders arr; seen holds the containers being rendered around it, so
+// that an array holding itself prints as [...] there instead of recursing.
+func (arr *Array) inspect(seen map[Object]bool) string {
+	if seen[arr] {
+		return "[...]"
+	}
+
+	seen[arr] = true
+	defer delete(seen, arr)
+
 	var out bytes.Buffer
 
 	elems := []string{}
 
 	for _, el := range arr.Elements {
-		elems = append(elems, el.Inspect())
+		elems = append(elems, inspect(el, seen))
 	}
 
 	out.WriteString("[")
@@ -128,18 +231,85 @@ type HashValue struct {
 	Value Object
 }
 
+// HashObject remembers insertion order: Pairs, Inspect and for loops visit keys
+// in the order they were first set, and overwriting a key keeps its position.
+// Keys are bucketed by hash and compared by value, so colliding hashes never
+// clobber each other. The zero value is an empty hash ready to use.
 type HashObject struct {
-	Value map[HashSet]HashValue
+	buckets map[HashSet][]int // Positions in pairs of the keys sharing a hash
+	pairs   []HashValue
+	hasher  func(Hashable) HashSet // Hashable.Hash when nil; tests swap it to force collisions
+}
+
+func (h *HashObject) hash(key Hashable) HashSet {
+	if h.hasher != nil {
+		return h.hasher(key)
+	}
+
+	return key.Hash()
+}
+
+// find returns the position of key in pairs, or -1.
+func (h *HashObject) find(hash HashSet, key Hashable) int {
+	for _, idx := range h.buckets[hash] {
+		if keysEqual(h.pairs[idx].Key.(Hashable), key) {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+func (h *HashObject) Get(key Hashable) (Object, bool) {
+	idx := h.find(h.hash(key), key)
+
+	if idx < 0 {
+		return nil, false
+	}
+
+	return h.pairs[idx].Value, true
 }
 
+func (h *HashObject) Set(key Hashable, value Object) {
+	hash := h.hash(key)
+
+	if idx := h.find(hash, key); idx >= 0 {
+		h.pairs[idx].Value = value
+		return
+	}
+
+	if h.buckets == nil {
+		h.buckets = map[HashSet][]int{}
+	}
+
+	h.buckets[hash] = append(h.buckets[hash], len(h.pairs))
+	h.pairs = append(h.pairs, HashValue{Key: frozenKey(key), Value: value})
+}
+
+func (h *HashObject) Len() int { return len(h.pairs) }
+
+// Pairs returns the pairs in insertion order. The slice must not be modified.
+func (h *HashObject) Pairs() []HashValue { return h.pairs }
+
 func (h *HashObject) Type() ObjectType { return HASH }
-func (h *HashObject) Inspect() string {
+func (h *HashObject) Inspect() string  { return h.inspect(map[Object]bool{}) }
+
+// inspect renders h like Array.inspect, printing {...} for a hash that holds
+// itself.
+func (h *HashObject) inspect(seen map[Object]bool) string {
+	if seen[h] {
+		return "{...}"
+	}
+
+	seen[h] = true
+	defer delete(seen, h)
+
 	var out bytes.Buffer
 
 	pairs := []string{}
 
-	for _, hash := range h.Value {
-		pairs = append(pairs, hash.Key.Inspect()+": "+hash.Value.Inspect())
+	for _, hash := range h.pairs {
+		pairs = append(pairs, inspect(hash.Key, seen)+": "+inspect(hash.Value, seen))
 	}
 
 	out.WriteString("{")
@@ -148,3 +318,15 @@ func (h *HashObject) Inspect() string {
 
 	return out.String()
 }
+
+// inspect renders obj as Inspect does, passing seen on to the containers.
+func inspect(obj Object, seen map[Object]bool) string {
+	switch obj := obj.(type) {
+	case *Array:
+		return obj.inspect(seen)
+	case *HashObject:
+		return obj.inspect(seen)
+	}
+
+	return obj.Inspect()
+}
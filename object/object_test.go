@@ -1,6 +1,12 @@
 package object
 
-import "testing"
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/rodmedeiross/monkey-interpreter/ast"
+)
 
 func TestStringHashKey(t *testing.T) {
 	hello1 := &String{Value: "Hello World"}
@@ -20,3 +26,328 @@ func TestStringHashKey(t *testing.T) {
 		t.Error("strings with different content have smae hash keys")
 	}
 }
+
+func TestFloatHashKey(t *testing.T) {
+	one := &Integer{Value: 1}
+	oneFloat := &Float{Value: 1.0}
+	half1 := &Float{Value: 0.5}
+	half2 := &Float{Value: 0.5}
+	zero := &Integer{Value: 0}
+	negativeZero := &Float{Value: math.Copysign(0, -1)}
+	nan1 := &Float{Value: math.NaN()}
+	nan2 := &Float{Value: -math.NaN()}
+
+	if one.Hash() != oneFloat.Hash() {
+		t.Error("integral float has different hash key than the equal integer")
+	}
+
+	if half1.Hash() != half2.Hash() {
+		t.Error("floats with same value have different hash keys")
+	}
+
+	if half1.Hash() == zero.Hash() || half1.Hash() == one.Hash() {
+		t.Error("non-integral float has same hash key as an integer")
+	}
+
+	if zero.Hash() != negativeZero.Hash() {
+		t.Error("-0.0 has different hash key than 0")
+	}
+
+	if nan1.Hash() != nan2.Hash() {
+		t.Error("NaN values have different hash keys")
+	}
+}
+
+func TestEnvironmentAssign(t *testing.T) {
+	outer := NewEnvironment()
+	outer.Set("x", &Integer{Value: 1})
+	inner := NewWrappedEnvironment(outer)
+
+	if _, ok := inner.Assign("x", &Integer{Value: 2}); !ok {
+		t.Fatal("Assign did not find x in the outer environment")
+	}
+
+	if obj, _ := outer.Get("x"); obj.(*Integer).Value != 2 {
+		t.Errorf("outer x was not updated, got=%s", obj.Inspect())
+	}
+
+	inner.Set("x", &Integer{Value: 3})
+	inner.Assign("x", &Integer{Value: 4})
+
+	if obj, _ := outer.Get("x"); obj.(*Integer).Value != 2 {
+		t.Errorf("outer x was updated through a shadowing binding, got=%s", obj.Inspect())
+	}
+
+	if _, ok := inner.Assign("y", &Integer{Value: 1}); ok {
+		t.Error("Assign declared an unknown name")
+	}
+
+	if _, ok := inner.Get("y"); ok {
+		t.Error("failed Assign left a binding behind")
+	}
+}
+
+func TestHashObjectOrder(t *testing.T) {
+	hash := &HashObject{}
+	hash.Set(&String{Value: "b"}, &Integer{Value: 1})
+	hash.Set(&Integer{Value: 7}, &Integer{Value: 2})
+	hash.Set(&String{Value: "a"}, &Integer{Value: 3})
+	hash.Set(&String{Value: "b"}, &Integer{Value: 4})
+
+	if hash.Len() != 3 {
+		t.Fatalf("hash has wrong length, expected=3, got=%d", hash.Len())
+	}
+
+	if hash.Inspect() != "{b: 4, 7: 2, a: 3}" {
+		t.Errorf("hash.Inspect() wrong, got=%q", hash.Inspect())
+	}
+
+	if _, ok := hash.Get(&String{Value: "c"}); ok {
+		t.Error("Get found a key that was never set")
+	}
+}
+
+func TestHashKeyTypes(t *testing.T) {
+	str := &String{Value: "Hello World"}
+	integer := &Integer{Value: int64(str.Hash().Value)}
+
+	if str.Hash().ObjectType != STRING_OBJ {
+		t.Errorf("string hash key has wrong type, expected=%q, got=%q", STRING_OBJ, str.Hash().ObjectType)
+	}
+
+	if str.Hash() == integer.Hash() {
+		t.Error("string has same hash key as the integer of its hash value")
+	}
+
+	hash := &HashObject{}
+	hash.Set(str, &Integer{Value: 1})
+	hash.Set(integer, &Integer{Value: 2})
+
+	if hash.Len() != 2 {
+		t.Fatalf("hash has wrong length, expected=2, got=%d", hash.Len())
+	}
+}
+
+func TestHashObjectCollisions(t *testing.T) {
+	// Every key lands in the same bucket.
+	hash := &HashObject{hasher: func(Hashable) HashSet { return HashSet{Value: 42} }}
+
+	keys := []Hashable{
+		&String{Value: "a"},
+		&String{Value: "b"},
+		&Integer{Value: 1},
+		&Float{Value: 1.5},
+		&Boolean{Value: true},
+		&Integer{Value: 0},
+		&Boolean{Value: false},
+	}
+
+	for i, key := range keys {
+		hash.Set(key, &Integer{Value: int64(i)})
+	}
+
+	if hash.Len() != len(keys) {
+		t.Fatalf("colliding keys overwrote each other, expected=%d pairs, got=%d", len(keys), hash.Len())
+	}
+
+	for i, key := range keys {
+		value, ok := hash.Get(key)
+
+		if !ok {
+			t.Errorf("key %s not found", key.Inspect())
+			continue
+		}
+
+		if value.(*Integer).Value != int64(i) {
+			t.Errorf("key %s has wrong value, expected=%d, got=%s", key.Inspect(), i, value.Inspect())
+		}
+	}
+
+	// Lookups compare by value, not identity.
+	hash.Set(&String{Value: "a"}, &Integer{Value: 10})
+	hash.Set(&Float{Value: 1.0}, &Integer{Value: 11})
+
+	if hash.Len() != len(keys) {
+		t.Fatalf("overwriting existing keys added pairs, expected=%d, got=%d", len(keys), hash.Len())
+	}
+
+	if value, _ := hash.Get(&String{Value: "a"}); value.(*Integer).Value != 10 {
+		t.Errorf("\"a\" was not overwritten, got=%s", value.Inspect())
+	}
+
+	if value, _ := hash.Get(&Integer{Value: 1}); value.(*Integer).Value != 11 {
+		t.Errorf("1 was not overwritten by 1.0, got=%s", value.Inspect())
+	}
+
+	if _, ok := hash.Get(&String{Value: "c"}); ok {
+		t.Error("Get found a colliding key that was never set")
+	}
+}
+
+func TestEqual(t *testing.T) {
+	fn := &Function{Body: &ast.BlockStatement{}}
+	nested := func() Object {
+		return &Array{Elements: []Object{&Integer{Value: 1}, &Array{Elements: []Object{&String{Value: "x"}}}}}
+	}
+	hash := func(keys ...string) Object {
+		h := &HashObject{}
+		for _, key := range keys {
+			h.Set(&String{Value: key}, &Integer{Value: int64(len(key))})
+		}
+		return h
+	}
+
+	tests := []struct {
+		a, b     Object
+		expected bool
+	}{
+		{&Integer{Value: 1}, &Integer{Value: 1}, true},
+		{&Integer{Value: 1}, &Float{Value: 1.0}, true},
+		{&Float{Value: 1.5}, &Integer{Value: 1}, false},
+		{&Float{Value: math.NaN()}, &Float{Value: math.NaN()}, false},
+		{&String{Value: "a"}, &String{Value: "a"}, true},
+		{&String{Value: "a"}, &String{Value: "b"}, false},
+		{&String{Value: "1"}, &Integer{Value: 1}, false},
+		{&Boolean{Value: true}, &Boolean{Value: true}, true},
+		{&Null{}, &Null{}, true},
+		{&Null{}, &Boolean{Value: false}, false},
+		{nested(), nested(), true},
+		{nested(), &Array{Elements: []Object{&Integer{Value: 1}}}, false},
+		{&Array{}, &Array{Elements: []Object{}}, true},
+		{hash("a", "bb"), hash("bb", "a"), true},
+		{hash("a", "bb"), hash("a"), false},
+		{hash("a"), hash("b"), false},
+		{&BigInt{Value: bigPow2(64)}, &BigInt{Value: bigPow2(64)}, true},
+		{&BigInt{Value: bigPow2(64)}, &Float{Value: math.Pow(2, 64)}, true},
+		{&Float{Value: math.Pow(2, 64)}, &BigInt{Value: new(big.Int).Add(bigPow2(64), big.NewInt(1))}, false},
+		{&BigInt{Value: bigPow2(64)}, &Float{Value: math.Inf(1)}, false},
+		{&BigInt{Value: big.NewInt(7)}, &Integer{Value: 7}, true},
+		{&Integer{Value: 7}, &BigInt{Value: bigPow2(64)}, false},
+		{fn, fn, true},
+		{fn, &Function{Body: fn.Body}, false},
+	}
+
+	for i, tt := range tests {
+		if got := Equal(tt.a, tt.b); got != tt.expected {
+			t.Errorf("tests[%d] - Equal(%s, %s) wrong, expected=%t, got=%t", i, tt.a.Inspect(), tt.b.Inspect(), tt.expected, got)
+		}
+	}
+}
+
+func TestBigIntHashKey(t *testing.T) {
+	twoTo64 := &BigInt{Value: bigPow2(64)}
+
+	if twoTo64.Hash() != (&BigInt{Value: bigPow2(64)}).Hash() {
+		t.Error("big ints with same value have different hash keys")
+	}
+
+	if twoTo64.Hash() != (&Float{Value: math.Pow(2, 64)}).Hash() {
+		t.Error("big int and equal float have different hash keys")
+	}
+
+	if (&BigInt{Value: big.NewInt(-3)}).Hash() != (&Integer{Value: -3}).Hash() {
+		t.Error("big int and equal integer have different hash keys")
+	}
+
+	inexact := &BigInt{Value: new(big.Int).Add(bigPow2(64), big.NewInt(1))}
+
+	if inexact.Hash() == twoTo64.Hash() {
+		t.Error("different big ints have same hash keys")
+	}
+
+	hash := &HashObject{}
+	hash.Set(twoTo64, &String{Value: "a"})
+	hash.Set(inexact, &String{Value: "b"})
+
+	if value, ok := hash.Get(&Float{Value: math.Pow(2, 64)}); !ok || value.Inspect() != "a" {
+		t.Errorf("wrong value for 2.0 ** 64, got=%v", value)
+	}
+
+	if value, ok := hash.Get(&BigInt{Value: new(big.Int).Add(bigPow2(64), big.NewInt(1))}); !ok || value.Inspect() != "b" {
+		t.Errorf("wrong value for 2 ** 64 + 1, got=%v", value)
+	}
+}
+
+func bigPow2(n uint) *big.Int {
+	return new(big.Int).Lsh(big.NewInt(1), n)
+}
+
+func TestArrayHashKey(t *testing.T) {
+	pair := func(a, b Object) *Array { return &Array{Elements: []Object{a, b}} }
+
+	if pair(&Integer{Value: 1}, &Integer{Value: 2}).Hash() != pair(&Integer{Value: 1}, &Integer{Value: 2}).Hash() {
+		t.Error("arrays with same elements have different hash keys")
+	}
+
+	if pair(&Integer{Value: 1}, &Integer{Value: 2}).Hash() == pair(&Integer{Value: 2}, &Integer{Value: 1}).Hash() {
+		t.Error("arrays with elements in different order have same hash keys")
+	}
+
+	if pair(&Integer{Value: 1}, &String{Value: "a"}).Hash() != pair(&Float{Value: 1.0}, &String{Value: "a"}).Hash() {
+		t.Error("arrays with equal numeric elements have different hash keys")
+	}
+
+	if (&Array{}).Hash() == (&Array{Elements: []Object{&Array{}}}).Hash() {
+		t.Error("empty array has same hash key as an array holding an empty array")
+	}
+
+	nested := &Array{Elements: []Object{&Integer{Value: 1}, pair(&String{Value: "a"}, &Function{})}}
+
+	if bad, path := nested.UnhashableElement(); bad == nil || path != "[1][1]" {
+		t.Errorf("UnhashableElement wrong, expected FUNCTION at [1][1], got=%v at %q", bad, path)
+	}
+
+	if bad, _ := pair(&Integer{Value: 1}, &Array{}).UnhashableElement(); bad != nil {
+		t.Errorf("UnhashableElement found %s in a hashable array", bad.Type())
+	}
+}
+
+func TestArrayKeyIsFrozen(t *testing.T) {
+	key := &Array{Elements: []Object{&Integer{Value: 1}, &Float{Value: math.NaN()}}}
+	hash := &HashObject{}
+	hash.Set(key, &String{Value: "value"})
+
+	key.Elements[0] = &Integer{Value: 2}
+
+	if _, ok := hash.Get(&Array{Elements: []Object{&Integer{Value: 1}, &Float{Value: math.NaN()}}}); !ok {
+		t.Error("array key changed when the original array was mutated")
+	}
+
+	if _, ok := hash.Get(key); ok {
+		t.Error("mutated array found under its old key")
+	}
+}
+
+func TestCyclicContainers(t *testing.T) {
+	arr := &Array{Elements: []Object{&Integer{Value: 1}, nil}}
+	arr.Elements[1] = arr
+
+	if arr.Inspect() != "[1, [...]]" {
+		t.Errorf("wrong Inspect for a cyclic array, got=%q", arr.Inspect())
+	}
+
+	hash := &HashObject{}
+	hash.Set(&String{Value: "self"}, hash)
+	hash.Set(&String{Value: "arr"}, arr)
+
+	if hash.Inspect() != "{self: {...}, arr: [1, [...]]}" {
+		t.Errorf("wrong Inspect for a cyclic hash, got=%q", hash.Inspect())
+	}
+
+	other := &Array{Elements: []Object{&Integer{Value: 1}, nil}}
+	other.Elements[1] = other
+
+	if !Equal(arr, other) {
+		t.Error("cyclic arrays of the same shape are not equal")
+	}
+
+	other.Elements[0] = &Integer{Value: 2}
+
+	if Equal(arr, other) {
+		t.Error("cyclic arrays with different elements are equal")
+	}
+
+	if bad, path := arr.UnhashableElement(); bad != arr || path != "[1]" {
+		t.Errorf("UnhashableElement wrong, expected the array itself at [1], got=%v at %q", bad, path)
+	}
+}
@@ -1,7 +1,9 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
+	"math/big"
 	"strconv"
 
 	"github.com/rodmedeiross/monkey-interpreter/ast"
@@ -12,11 +14,20 @@ import (
 const (
 	_ int = iota
 	LOWEST
+	ASSIGN      // = +=
+	NULLISH     // ??
+	LOGICAL_OR  // ||
+	LOGICAL_AND // &&
 	EQUALS      // ==
 	LESSGREATER // < >
+	BITWISE_OR  // |
+	BITWISE_XOR // ^
+	BITWISE_AND // &
+	SHIFT       // << >>
 	SUM         // + -
-	PRODUCT     // * /
+	PRODUCT     // * / %
 	PREFIX      // !X ++X
+	POWER       // **
 	CALL        // X(X)
 	INDEX
 )
@@ -27,6 +38,8 @@ type (
 )
 
 var precedence = map[token.TokenType]int{
+	token.OR:       LOGICAL_OR,
+	token.AND:      LOGICAL_AND,
 	token.EQ:       EQUALS,
 	token.NOT_EQ:   EQUALS,
 	token.LT:       LESSGREATER,
@@ -39,13 +52,35 @@ var precedence = map[token.TokenType]int{
 	token.SLASH:    PRODUCT,
 	token.LPAREN:   CALL,
 	token.LCOL:     INDEX,
+
+	token.PIPE:      BITWISE_OR,
+	token.CARET:     BITWISE_XOR,
+	token.AMPERSAND: BITWISE_AND,
+	token.SHL:       SHIFT,
+	token.SHR:       SHIFT,
+	token.PERCENT:   PRODUCT,
+	token.POWER:     POWER,
+
+	token.NULLISH:       NULLISH,
+	token.QUESTION_DOT:  INDEX,
+	token.DOT:           INDEX,
+	token.QUESTION_LCOL: INDEX,
+
+	token.ASSIGN:          ASSIGN,
+	token.PLUS_ASSIGN:     ASSIGN,
+	token.MINUS_ASSIGN:    ASSIGN,
+	token.ASTERISK_ASSIGN: ASSIGN,
+	token.SLASH_ASSIGN:    ASSIGN,
 }
 
 type Parser struct {
 	lexer     *lexer.Lexer
 	currToken *token.Token
 	peekToken *token.Token
-	errors    []string
+	errors    []*ParserError
+	panicking bool
+	loopDepth int // Number of loops enclosing the current token, reset by function bodies
+	hashDepth int // Number of hash literals open in the current block, see synchronize
 
 	prefixParserFns map[token.TokenType]prefixParserFn
 	infixParserFns  map[token.TokenType]infixParserFn
@@ -54,7 +89,7 @@ type Parser struct {
 func New(l *lexer.Lexer) *Parser {
 	p := &Parser{
 		lexer:  l,
-		errors: []string{},
+		errors: []*ParserError{},
 	}
 
 	p.prefixParserFns = make(map[token.TokenType]prefixParserFn)
@@ -62,17 +97,24 @@ func New(l *lexer.Lexer) *Parser {
 
 	p.addPrefixFn(token.IDENT, p.parseIdentifier)
 	p.addPrefixFn(token.INT, p.parseInteger)
+	p.addPrefixFn(token.FLOAT, p.parseFloat)
 	p.addPrefixFn(token.BANG, p.parsePrefix)
 	p.addPrefixFn(token.MINUS, p.parsePrefix)
+	p.addPrefixFn(token.TILDE, p.parsePrefix)
 	p.addPrefixFn(token.TRUE, p.parseBoolean)
 	p.addPrefixFn(token.FALSE, p.parseBoolean)
+	p.addPrefixFn(token.NULL, p.parseNull)
 	p.addPrefixFn(token.LPAREN, p.parseGroupedExpression)
 	p.addPrefixFn(token.IF, p.parseIfExpression)
 	p.addPrefixFn(token.FUNCTION, p.parseFunctionExpression)
 	p.addPrefixFn(token.STRING, p.parseStringExpression)
+	p.addPrefixFn(token.TEMPLATE_HEAD, p.parseInterpolatedString)
 	p.addPrefixFn(token.LCOL, p.parseArrayExpression)
 	p.addPrefixFn(token.LBRACE, p.parseHashExpression)
+	p.addPrefixFn(token.ERROR, p.parseLexerError)
 
+	p.addInfixFn(token.OR, p.parseInfix)
+	p.addInfixFn(token.AND, p.parseInfix)
 	p.addInfixFn(token.EQ, p.parseInfix)
 	p.addInfixFn(token.NOT_EQ, p.parseInfix)
 	p.addInfixFn(token.LT_EQ, p.parseInfix)
@@ -83,9 +125,25 @@ func New(l *lexer.Lexer) *Parser {
 	p.addInfixFn(token.MINUS, p.parseInfix)
 	p.addInfixFn(token.ASTERISK, p.parseInfix)
 	p.addInfixFn(token.SLASH, p.parseInfix)
+	p.addInfixFn(token.PERCENT, p.parseInfix)
+	p.addInfixFn(token.POWER, p.parseInfix)
+	p.addInfixFn(token.AMPERSAND, p.parseInfix)
+	p.addInfixFn(token.PIPE, p.parseInfix)
+	p.addInfixFn(token.CARET, p.parseInfix)
+	p.addInfixFn(token.SHL, p.parseInfix)
+	p.addInfixFn(token.SHR, p.parseInfix)
 	p.addInfixFn(token.STRING, p.parseInfix)
 	p.addInfixFn(token.LPAREN, p.parseFunctionCall)
 	p.addInfixFn(token.LCOL, p.parseIndexExpression)
+	p.addInfixFn(token.QUESTION_LCOL, p.parseIndexExpression)
+	p.addInfixFn(token.DOT, p.parseMemberExpression)
+	p.addInfixFn(token.QUESTION_DOT, p.parseMemberExpression)
+	p.addInfixFn(token.NULLISH, p.parseInfix)
+	p.addInfixFn(token.ASSIGN, p.parseAssignExpression)
+	p.addInfixFn(token.PLUS_ASSIGN, p.parseAssignExpression)
+	p.addInfixFn(token.MINUS_ASSIGN, p.parseAssignExpression)
+	p.addInfixFn(token.ASTERISK_ASSIGN, p.parseAssignExpression)
+	p.addInfixFn(token.SLASH_ASSIGN, p.parseAssignExpression)
 
 	p.nextToken()
 	p.nextToken()
@@ -93,7 +151,7 @@ func New(l *lexer.Lexer) *Parser {
 	return p
 }
 
-func (p *Parser) Errors() []string {
+func (p *Parser) Errors() []*ParserError {
 	return p.errors
 }
 
@@ -136,11 +194,29 @@ func (p *Parser) parseIdentifier() ast.Expression {
 
 func (p *Parser) parseInteger() ast.Expression {
 	defer untrace(trace("parseInteger"))
-	intLiteral, err := strconv.ParseInt(p.currToken.Literal, 0, 64)
+	literal := p.currToken.Literal
+
+	// Without a base prefix a literal is decimal, so a leading zero is rejected
+	// rather than read as C-style octal.
+	if len(literal) > 1 && literal[0] == '0' && (literal[1] == '_' || '0' <= literal[1] && literal[1] <= '9') {
+		p.addError(p.currToken, INVALID_INTEGER, "", "malformed integer literal %s: leading zeros are not allowed", literal)
+		return nil
+	}
+
+	intLiteral, err := strconv.ParseInt(literal, 0, 64)
+
+	// A literal outside int64 is a big integer, as arithmetic would make it.
+	if errors.Is(err, strconv.ErrRange) {
+		if value, ok := new(big.Int).SetString(literal, 0); ok {
+			return &ast.BigIntegerExpression{
+				Token: *p.currToken,
+				Value: value,
+			}
+		}
+	}
 
 	if err != nil {
-		msg := fmt.Sprintf("Failed to convert %q into an integer", intLiteral)
-		p.errors = append(p.errors, msg)
+		p.addError(p.currToken, INVALID_INTEGER, "", "malformed integer literal %s", literal)
 		return nil
 	}
 
@@ -150,6 +226,21 @@ func (p *Parser) parseInteger() ast.Expression {
 	}
 }
 
+func (p *Parser) parseFloat() ast.Expression {
+	defer untrace(trace("parseFloat"))
+	floatLiteral, err := strconv.ParseFloat(p.currToken.Literal, 64)
+
+	if err != nil {
+		p.addError(p.currToken, INVALID_FLOAT, "", "Failed to convert %q into a float", p.currToken.Literal)
+		return nil
+	}
+
+	return &ast.FloatExpression{
+		Token: *p.currToken,
+		Value: floatLiteral,
+	}
+}
+
 func (p *Parser) parseBoolean() ast.Expression {
 	defer untrace(trace("parseBoolean"))
 
@@ -159,6 +250,21 @@ func (p *Parser) parseBoolean() ast.Expression {
 	}
 }
 
+func (p *Parser) parseNull() ast.Expression {
+	defer untrace(trace("parseNull"))
+
+	return &ast.NullExpression{Token: *p.currToken}
+}
+
+// parseLexerError reports a token the lexer could not read, such as an
+// unterminated string, with the lexer's own message.
+func (p *Parser) parseLexerError() ast.Expression {
+	defer untrace(trace("parseLexerError"))
+
+	p.addError(p.currToken, LEXER_ERROR, "", "%s", p.currToken.Literal)
+	return nil
+}
+
 func (p *Parser) parsePrefix() ast.Expression {
 	defer untrace(trace("parsePrefix"))
 	prefixExpression := &ast.PrefixExpression{
@@ -169,6 +275,7 @@ func (p *Parser) parsePrefix() ast.Expression {
 	p.nextToken()
 
 	prefixExpression.Right = p.parseExpression(PREFIX)
+	prefixExpression.EndPos = p.currToken.End
 
 	return prefixExpression
 }
@@ -182,12 +289,52 @@ func (p *Parser) parseInfix(left ast.Expression) ast.Expression {
 	}
 
 	precedence := p.currPrecedence()
+
+	// ** is right associative: 2 ** 3 ** 2 is 2 ** (3 ** 2).
+	if p.currTokenIs(token.POWER) {
+		precedence--
+	}
+
 	p.nextToken()
 	infixExpression.Right = p.parseExpression(precedence)
+	infixExpression.EndPos = p.currToken.End
 
 	return infixExpression
 }
 
+func (p *Parser) parseAssignExpression(left ast.Expression) ast.Expression {
+	defer untrace(trace("parseAssignExpression"))
+	assignExpression := &ast.AssignExpression{
+		Token:    *p.currToken,
+		Target:   left,
+		Operator: p.currToken.Literal,
+	}
+
+	switch left := left.(type) {
+	case *ast.Identifier:
+	case *ast.IndexExpression:
+		if left.Optional {
+			p.addError(p.currToken, INVALID_ASSIGNMENT, "", "cannot assign to optional access %s", left)
+			return nil
+		}
+	case *ast.MemberExpression:
+		if left.Optional {
+			p.addError(p.currToken, INVALID_ASSIGNMENT, "", "cannot assign to optional access %s", left)
+			return nil
+		}
+	default:
+		p.addError(p.currToken, INVALID_ASSIGNMENT, "", "cannot assign to %s", left)
+		return nil
+	}
+
+	// Assignment is right associative: a = b = 1 is a = (b = 1).
+	p.nextToken()
+	assignExpression.Value = p.parseExpression(ASSIGN - 1)
+	assignExpression.EndPos = p.currToken.End
+
+	return assignExpression
+}
+
 func (p *Parser) parseGroupedExpression() ast.Expression {
 	defer untrace(trace("parseGroupedExpression"))
 	p.nextToken()
@@ -236,6 +383,8 @@ func (p *Parser) parseIfExpression() ast.Expression {
 		ifExpression.Alternative = p.parseBlockStatement()
 	}
 
+	ifExpression.EndPos = p.currToken.End
+
 	return ifExpression
 }
 
@@ -255,7 +404,13 @@ func (p *Parser) parseFunctionExpression() ast.Expression {
 		return nil
 	}
 
+	// break and continue cannot cross a function boundary.
+	loopDepth := p.loopDepth
+	p.loopDepth = 0
 	funcExpress.Body = p.parseBlockStatement()
+	p.loopDepth = loopDepth
+
+	funcExpress.EndPos = p.currToken.End
 
 	return funcExpress
 }
@@ -268,6 +423,7 @@ func (p *Parser) parseArrayExpression() ast.Expression {
 	}
 
 	arrayExpress.Values = p.parseSequencialValues(token.RCOL)
+	arrayExpress.EndPos = p.currToken.End
 
 	return arrayExpress
 }
@@ -281,7 +437,7 @@ func (p *Parser) parseFunctionParameters() []*ast.Identifier {
 	for _, v := range values {
 		ident, ok := v.(*ast.Identifier)
 		if !ok {
-			p.errors = append(p.errors, fmt.Sprintf("expected *ast.Identifier for function parameters, got=%T (%+v)", v, v))
+			p.addError(p.currToken, INVALID_PARAMETER, token.IDENT, "expected *ast.Identifier for function parameters, got=%T (%+v)", v, v)
 			return nil
 		}
 		identifiers = append(identifiers, ident)
@@ -321,14 +477,31 @@ func (p *Parser) parseBlockStatement() *ast.BlockStatement {
 		Statements: []ast.Statement{},
 	}
 
+	// Hash literals around the block are not the block's to close.
+	hashDepth := p.hashDepth
+	p.hashDepth = 0
+	defer func() { p.hashDepth = hashDepth }()
+
 	p.nextToken()
 
 	for !p.currTokenIs(token.RBRACE) && !p.currTokenIs(token.EOF) {
 		stmt := p.parseStatement()
-		blockStatment.Statements = append(blockStatment.Statements, stmt)
+
+		if p.panicking {
+			p.synchronize()
+
+			if p.currTokenIs(token.RBRACE) {
+				break
+			}
+		} else {
+			blockStatment.Statements = append(blockStatment.Statements, stmt)
+		}
+
 		p.nextToken()
 	}
 
+	blockStatment.EndPos = p.currToken.End
+
 	return blockStatment
 }
 
@@ -340,39 +513,108 @@ func (p *Parser) parseFunctionCall(function ast.Expression) ast.Expression {
 	}
 
 	call.FunctionCallParameters = p.parseSequencialValues(token.RPAREN)
+	call.EndPos = p.currToken.End
 
 	return call
 }
 
+// parseIndexExpression parses left[index], or left[low:high] with either
+// bound optional, which is a SliceExpression.
 func (p *Parser) parseIndexExpression(left ast.Expression) ast.Expression {
-	defer untrace(trace("parseArrayExpression"))
+	defer untrace(trace("parseIndexExpression"))
 
 	indexExpression := &ast.IndexExpression{
-		Token: *p.currToken,
-		Left:  left,
+		Token:    *p.currToken,
+		Left:     left,
+		Optional: p.currTokenIs(token.QUESTION_LCOL),
+	}
+
+	if p.peekTokenIs(token.DOUBLECOL) {
+		p.nextToken()
+		return p.parseSliceExpression(indexExpression)
 	}
 
 	p.nextToken()
 
 	indexExpression.Index = p.parseExpression(LOWEST)
 
+	if p.peekTokenIs(token.DOUBLECOL) {
+		p.nextToken()
+		return p.parseSliceExpression(indexExpression)
+	}
+
 	if !p.expectedToken(token.RCOL) {
 		return nil
 	}
 
+	indexExpression.EndPos = p.currToken.End
+
 	return indexExpression
 }
 
+// parseSliceExpression parses the rest of a slice from its colon. The index
+// already parsed, if any, is the low bound.
+func (p *Parser) parseSliceExpression(index *ast.IndexExpression) ast.Expression {
+	defer untrace(trace("parseSliceExpression"))
+
+	sliceExpression := &ast.SliceExpression{
+		Token:    index.Token,
+		Left:     index.Left,
+		Low:      index.Index,
+		Optional: index.Optional,
+	}
+
+	if !p.peekTokenIs(token.RCOL) {
+		p.nextToken()
+		sliceExpression.High = p.parseExpression(LOWEST)
+	}
+
+	if !p.expectedToken(token.RCOL) {
+		return nil
+	}
+
+	sliceExpression.EndPos = p.currToken.End
+
+	return sliceExpression
+}
+
+func (p *Parser) parseMemberExpression(left ast.Expression) ast.Expression {
+	defer untrace(trace("parseMemberExpression"))
+
+	memberExpression := &ast.MemberExpression{
+		Token:    *p.currToken,
+		Object:   left,
+		Optional: p.currTokenIs(token.QUESTION_DOT),
+	}
+
+	if !p.expectedToken(token.IDENT) {
+		return nil
+	}
+
+	memberExpression.Property = &ast.Identifier{
+		Token: *p.currToken,
+		Value: p.currToken.Literal,
+	}
+	memberExpression.EndPos = p.currToken.End
+
+	return memberExpression
+}
+
 func (p *Parser) parseHashExpression() ast.Expression {
 	defer untrace(trace("parseHashExpression"))
 
 	hash := &ast.HashExpression{
 		Token: *p.currToken,
-		Pairs: map[ast.Expression]ast.Expression{},
 	}
 
+	// Left open when the literal fails to parse, so that synchronize skips to
+	// its closing brace.
+	p.hashDepth++
+
 	if p.peekTokenIs(token.RBRACE) {
 		p.nextToken()
+		p.hashDepth--
+		hash.EndPos = p.currToken.End
 		return hash
 	}
 
@@ -389,7 +631,7 @@ func (p *Parser) parseHashExpression() ast.Expression {
 
 		value := p.parseExpression(LOWEST)
 
-		hash.Pairs[key] = value
+		hash.Pairs = append(hash.Pairs, ast.HashPair{Key: key, Value: value})
 
 		if !p.peekTokenIs(token.RBRACE) && !p.expectedToken(token.COMMA) {
 			return nil
@@ -400,6 +642,9 @@ func (p *Parser) parseHashExpression() ast.Expression {
 		return nil
 	}
 
+	p.hashDepth--
+	hash.EndPos = p.currToken.End
+
 	return hash
 }
 
@@ -409,7 +654,15 @@ func (p *Parser) ParserProgram() *ast.Program {
 
 	for p.currToken.Type != token.EOF {
 		statement := p.parseStatement()
-		program.Statements = append(program.Statements, statement)
+
+		// A broken statement is dropped and the parser skips ahead to the next
+		// synchronization point, so one typo yields a single error.
+		if p.panicking {
+			p.synchronize()
+		} else {
+			program.Statements = append(program.Statements, statement)
+		}
+
 		p.nextToken()
 	}
 
@@ -422,6 +675,14 @@ func (p *Parser) parseStatement() ast.Statement {
 		return p.parseLetStatement()
 	case token.RETURN:
 		return p.parseReturnStatement()
+	case token.WHILE:
+		return p.parseWhileStatement()
+	case token.FOR:
+		return p.parseForStatement()
+	case token.BREAK:
+		return p.parseBreakStatement()
+	case token.CONTINUE:
+		return p.parseContinueStatement()
 	default:
 		return p.parseExpressionStatement()
 	}
@@ -447,10 +708,16 @@ func (p *Parser) parseLetStatement() *ast.LetStatement {
 
 	letStatement.Value = p.parseExpression(LOWEST)
 
+	if fn, ok := letStatement.Value.(*ast.FunctionExpression); ok {
+		fn.Name = letStatement.Name.Value
+	}
+
 	if p.peekTokenIs(token.SEMICOLON) {
 		p.nextToken()
 	}
 
+	letStatement.EndPos = p.currToken.End
+
 	return letStatement
 }
 
@@ -468,9 +735,132 @@ func (p *Parser) parseReturnStatement() *ast.ReturnStatement {
 		p.nextToken()
 	}
 
+	returnStatement.EndPos = p.currToken.End
+
 	return returnStatement
 }
 
+func (p *Parser) parseWhileStatement() *ast.WhileStatement {
+	defer untrace(trace("parseWhileStatement"))
+	whileStatement := &ast.WhileStatement{
+		Token: *p.currToken,
+	}
+
+	if !p.expectedToken(token.LPAREN) {
+		return nil
+	}
+
+	p.nextToken()
+	whileStatement.Condition = p.parseExpression(LOWEST)
+
+	if !p.expectedToken(token.RPAREN) {
+		return nil
+	}
+
+	if !p.expectedToken(token.LBRACE) {
+		return nil
+	}
+
+	whileStatement.Body = p.parseLoopBody()
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	whileStatement.EndPos = p.currToken.End
+
+	return whileStatement
+}
+
+func (p *Parser) parseForStatement() *ast.ForStatement {
+	defer untrace(trace("parseForStatement"))
+	forStatement := &ast.ForStatement{
+		Token: *p.currToken,
+	}
+
+	if !p.expectedToken(token.LPAREN) {
+		return nil
+	}
+
+	if !p.expectedToken(token.IDENT) {
+		return nil
+	}
+
+	forStatement.Variable = p.parseIdentifier().(*ast.Identifier)
+
+	if !p.expectedToken(token.IN) {
+		return nil
+	}
+
+	p.nextToken()
+	forStatement.Iterable = p.parseExpression(LOWEST)
+
+	if !p.expectedToken(token.RPAREN) {
+		return nil
+	}
+
+	if !p.expectedToken(token.LBRACE) {
+		return nil
+	}
+
+	forStatement.Body = p.parseLoopBody()
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	forStatement.EndPos = p.currToken.End
+
+	return forStatement
+}
+
+func (p *Parser) parseLoopBody() *ast.BlockStatement {
+	p.loopDepth++
+	defer func() { p.loopDepth-- }()
+
+	return p.parseBlockStatement()
+}
+
+func (p *Parser) parseBreakStatement() *ast.BreakStatement {
+	defer untrace(trace("parseBreakStatement"))
+	breakStatement := &ast.BreakStatement{
+		Token: *p.currToken,
+	}
+
+	if p.loopDepth == 0 {
+		p.addError(p.currToken, OUTSIDE_LOOP, "", "%q outside of a loop", p.currToken.Literal)
+		return nil
+	}
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	breakStatement.EndPos = p.currToken.End
+
+	return breakStatement
+}
+
+func (p *Parser) parseContinueStatement() *ast.ContinueStatement {
+	defer untrace(trace("parseContinueStatement"))
+	continueStatement := &ast.ContinueStatement{
+		Token: *p.currToken,
+	}
+
+	if p.loopDepth == 0 {
+		p.addError(p.currToken, OUTSIDE_LOOP, "", "%q outside of a loop", p.currToken.Literal)
+		return nil
+	}
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	continueStatement.EndPos = p.currToken.End
+
+	return continueStatement
+}
+
 func (p *Parser) parseExpressionStatement() *ast.ExpressionStatement {
 	defer untrace(trace("parseExpressionStatement"))
 	expStatement := &ast.ExpressionStatement{
@@ -483,6 +873,8 @@ func (p *Parser) parseExpressionStatement() *ast.ExpressionStatement {
 		p.nextToken()
 	}
 
+	expStatement.EndPos = p.currToken.End
+
 	return expStatement
 }
 
@@ -491,8 +883,7 @@ func (p *Parser) parseExpression(precedence int) ast.Expression {
 	prefix := p.prefixParserFns[p.currToken.Type]
 
 	if prefix == nil {
-		msg := fmt.Sprintf("a prefix parser function for %q not found", p.currToken.Type)
-		p.errors = append(p.errors, msg)
+		p.addError(p.currToken, NO_PREFIX_PARSER, "", "a prefix parser function for %q not found", p.currToken.Type)
 		return nil
 	}
 
@@ -523,6 +914,37 @@ func (p *Parser) parseStringExpression() ast.Expression {
 	}
 }
 
+func (p *Parser) parseInterpolatedString() ast.Expression {
+	defer untrace(trace("parseInterpolatedString"))
+
+	str := &ast.InterpolatedString{
+		Token: *p.currToken,
+		Parts: []ast.Expression{p.parseStringExpression()},
+	}
+
+	for {
+		p.nextToken()
+		str.Parts = append(str.Parts, p.parseExpression(LOWEST))
+
+		if p.peekTokenIs(token.TEMPLATE_MIDDLE) {
+			p.nextToken()
+			str.Parts = append(str.Parts, p.parseStringExpression())
+			continue
+		}
+
+		if !p.expectedToken(token.TEMPLATE_TAIL) {
+			return nil
+		}
+
+		str.Parts = append(str.Parts, p.parseStringExpression())
+		break
+	}
+
+	str.EndPos = p.currToken.End
+
+	return str
+}
+
 func (p *Parser) peekTokenIs(token token.TokenType) bool {
 	return p.peekToken.Type == token
 }
@@ -541,7 +963,30 @@ func (p *Parser) expectedToken(token token.TokenType) bool {
 	}
 }
 
-func (p *Parser) peekError(token token.TokenType) {
-	msg := fmt.Sprintf("[PARSER] - Failed to parse %q, got=%q", token, p.peekToken.Type)
-	p.errors = append(p.errors, msg)
+func (p *Parser) peekError(expected token.TokenType) {
+	if p.peekTokenIs(token.ERROR) {
+		p.addError(p.peekToken, LEXER_ERROR, expected, "%s", p.peekToken.Literal)
+		return
+	}
+
+	p.addError(p.peekToken, UNEXPECTED_TOKEN, expected, "[PARSER] - Failed to parse %q, got=%q", expected, p.peekToken.Type)
+}
+
+// addError records a parser error at the offending token got. While the parser is panicking, i.e.
+// after an error and before synchronize, further errors are follow-ups of the
+// first one and are not recorded.
+func (p *Parser) addError(got *token.Token, code string, expected token.TokenType, format string, args ...any) {
+	if p.panicking {
+		return
+	}
+
+	p.panicking = true
+	p.errors = append(p.errors, &ParserError{
+		Code:     code,
+		Pos:      got.Start,
+		End:      got.End,
+		Expected: expected,
+		Got:      got.Type,
+		Message:  fmt.Sprintf(format, args...),
+	})
 }
@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/rodmedeiross/monkey-interpreter/diagnostics"
+	"github.com/rodmedeiross/monkey-interpreter/token"
+)
+
+const (
+	UNEXPECTED_TOKEN   = "P0001"
+	NO_PREFIX_PARSER   = "P0002"
+	INVALID_INTEGER    = "P0003"
+	INVALID_PARAMETER  = "P0004"
+	INVALID_FLOAT      = "P0005"
+	OUTSIDE_LOOP       = "P0006"
+	INVALID_ASSIGNMENT = "P0007"
+	LEXER_ERROR        = "P0008"
+)
+
+// ParserError describes a single syntax error. Expected is empty when the
+// parser was not looking for a specific token.
+type ParserError struct {
+	Code     string
+	Pos      token.Position
+	End      token.Position
+	Expected token.TokenType
+	Got      token.TokenType
+	Message  string
+}
+
+func (pe *ParserError) Error() string {
+	return fmt.Sprintf("%s: %s", pe.Pos, pe.Message)
+}
+
+func (pe *ParserError) Diagnostic() *diagnostics.Diagnostic {
+	return &diagnostics.Diagnostic{
+		Severity: diagnostics.ERROR,
+		Code:     pe.Code,
+		Span:     diagnostics.Span{Start: pe.Pos, End: pe.End},
+		Message:  pe.Message,
+	}
+}
+
+// synchronize discards tokens after a syntax error until the parser reaches a
+// point where a new statement can start: a semicolon, a closing brace of the
+// enclosing block or a statement keyword. Braces opened while skipping are
+// skipped as a whole, so the rest of a broken block does not leak out of it,
+// and so are the hash literals the error left open.
+//
+// When the error is on the closing brace of the enclosing block itself, the
+// parser stops on it, without consuming it, so that the block can end there.
+func (p *Parser) synchronize() {
+	depth := p.hashDepth
+	p.hashDepth = 0
+
+	for !p.currTokenIs(token.EOF) {
+		if depth == 0 {
+			if p.currTokenIs(token.SEMICOLON) || p.currTokenIs(token.RBRACE) {
+				break
+			}
+
+			if p.peekTokenIs(token.RBRACE) || p.peekTokenIs(token.EOF) || isStatementKeyword(p.peekToken.Type) {
+				break
+			}
+		}
+
+		switch p.currToken.Type {
+		case token.LBRACE:
+			depth++
+		case token.RBRACE:
+			depth--
+		}
+
+		p.nextToken()
+	}
+
+	p.panicking = false
+}
+
+func isStatementKeyword(tokenType token.TokenType) bool {
+	switch tokenType {
+	case token.LET, token.RETURN, token.WHILE, token.FOR, token.BREAK, token.CONTINUE:
+		return true
+	}
+
+	return false
+}
@@ -2,10 +2,13 @@ package parser
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/rodmedeiross/monkey-interpreter/ast"
+	"github.com/rodmedeiross/monkey-interpreter/diagnostics"
 	"github.com/rodmedeiross/monkey-interpreter/lexer"
+	"github.com/rodmedeiross/monkey-interpreter/token"
 )
 
 func TestParsingLetStatements(t *testing.T) {
@@ -126,6 +129,174 @@ func TestParsingIntegerExpression(t *testing.T) {
 	}
 }
 
+func TestParsingIntegerBases(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"0xFF", 255},
+		{"0Xff", 255},
+		{"0o17", 15},
+		{"0b1010", 10},
+		{"1_000_000", 1000000},
+		{"0", 0},
+		{"0x_7fff_ffff_ffff_ffff", 9223372036854775807},
+	}
+
+	for _, tt := range tests {
+		parser := New(lexer.New(tt.input))
+
+		program := parser.ParserProgram()
+		checkParserErros(t, parser)
+
+		expression, ok := program.Statements[0].(*ast.ExpressionStatement)
+
+		if !ok {
+			t.Fatalf("program.Statements[0] is not *ast.ExpressionStatement, got=%T", program.Statements[0])
+		}
+
+		integer, ok := expression.Expression.(*ast.IntegerExpression)
+
+		if !ok {
+			t.Fatalf("expression is not *ast.IntegerExpression, got=%T", expression.Expression)
+		}
+
+		if integer.Value != tt.expected {
+			t.Errorf("%s: wrong value, expected=%d, got=%d", tt.input, tt.expected, integer.Value)
+		}
+
+		if integer.String() != tt.input {
+			t.Errorf("%s: wrong String, got=%q", tt.input, integer.String())
+		}
+	}
+}
+
+func TestParsingBigIntegerExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"9223372036854775808", "9223372036854775808"},
+		{"18_446_744_073_709_551_616", "18446744073709551616"},
+		{"0x1_0000_0000_0000_0000", "18446744073709551616"},
+		{"0b1" + strings.Repeat("0", 64), "18446744073709551616"},
+		{"0o7777777777777777777777777", "37778931862957161709567"},
+	}
+
+	for _, tt := range tests {
+		parser := New(lexer.New(tt.input))
+
+		program := parser.ParserProgram()
+		checkParserErros(t, parser)
+
+		expression, ok := program.Statements[0].(*ast.ExpressionStatement)
+
+		if !ok {
+			t.Fatalf("program.Statements[0] is not *ast.ExpressionStatement, got=%T", program.Statements[0])
+		}
+
+		integer, ok := expression.Expression.(*ast.BigIntegerExpression)
+
+		if !ok {
+			t.Fatalf("expression is not *ast.BigIntegerExpression, got=%T", expression.Expression)
+		}
+
+		if integer.Value.String() != tt.expected {
+			t.Errorf("%s: wrong value, expected=%s, got=%s", tt.input, tt.expected, integer.Value)
+		}
+
+		if integer.String() != tt.input {
+			t.Errorf("%s: wrong String, got=%q", tt.input, integer.String())
+		}
+	}
+
+	// -9223372036854775808 is the negation of a literal outside int64.
+	parser := New(lexer.New("-9223372036854775808"))
+	program := parser.ParserProgram()
+	checkParserErros(t, parser)
+
+	if program.String() != "(-9223372036854775808)" {
+		t.Errorf("wrong program, got=%q", program.String())
+	}
+}
+
+func TestParsingInvalidInteger(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"0b102", "1:1: malformed integer literal 0b102"},
+		{"0x", "1:1: malformed integer literal 0x"},
+		{"1__0", "1:1: malformed integer literal 1__0"},
+		{"let x = 7_;", "1:9: malformed integer literal 7_"},
+		{"017", "1:1: malformed integer literal 017: leading zeros are not allowed"},
+		{"09", "1:1: malformed integer literal 09: leading zeros are not allowed"},
+		{"0_1", "1:1: malformed integer literal 0_1: leading zeros are not allowed"},
+	}
+
+	for _, tt := range tests {
+		parser := New(lexer.New(tt.input))
+		parser.ParserProgram()
+
+		errs := parser.Errors()
+
+		if len(errs) != 1 {
+			t.Errorf("%s: expected 1 error, got=%d", tt.input, len(errs))
+			continue
+		}
+
+		if errs[0].Code != INVALID_INTEGER || errs[0].Error() != tt.expected {
+			t.Errorf("wrong error, expected=%q, got=%s %q", tt.expected, errs[0].Code, errs[0].Error())
+		}
+	}
+}
+
+func TestParsingFloatExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+	}{
+		{"3.14;", 3.14},
+		{"1e3", 1000},
+		{"1.5e-3", 0.0015},
+	}
+
+	for _, tt := range tests {
+		lexer := lexer.New(tt.input)
+		parser := New(lexer)
+
+		program := parser.ParserProgram()
+		checkParserErros(t, parser)
+
+		if len(program.Statements) != 1 {
+			t.Errorf("program.Statements does not contain 1 statement, got=%d", len(program.Statements))
+		}
+
+		expression, ok := program.Statements[0].(*ast.ExpressionStatement)
+
+		if !ok {
+			t.Errorf("program.Statements[0] is not *ast.ExpressionStatement, got=%T", program.Statements[0])
+		}
+
+		testFloatExpression(t, tt.expected, expression.Expression)
+	}
+}
+
+func TestParsingFloatOutOfRange(t *testing.T) {
+	parser := New(lexer.New("1e999"))
+	parser.ParserProgram()
+
+	errs := parser.Errors()
+
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got=%d", len(errs))
+	}
+
+	if errs[0].Error() != `1:1: Failed to convert "1e999" into a float` {
+		t.Errorf("wrong error, got=%q", errs[0].Error())
+	}
+}
+
 func TestParsingPrefixExpression(t *testing.T) {
 	prefixTests := []struct {
 		input      string
@@ -134,8 +305,10 @@ func TestParsingPrefixExpression(t *testing.T) {
 	}{
 		{"!5", "!", 5},
 		{"-12", "-", 12},
+		{"-1.5", "-", 1.5},
 		{"!true", "!", true},
 		{"!false", "!", false},
+		{"~5", "~", 5},
 	}
 
 	for _, pt := range prefixTests {
@@ -182,11 +355,22 @@ func TestParsingInfixExpression(t *testing.T) {
 		{"5 - 5", 5, "-", 5},
 		{"5 * 5", 5, "*", 5},
 		{"5 / 5", 5, "/", 5},
+		{"2.5 * 4", 2.5, "*", 4},
+		{"1 < 0.5", 1, "<", 0.5},
 		{"true == true", true, "==", true},
 		{"false == false", false, "==", false},
 		{"true != false", true, "!=", false},
 		{"1 <= 1", 1, "<=", 1},
 		{"1 >= 1", 1, ">=", 1},
+		{"true && false", true, "&&", false},
+		{"a || b", "a", "||", "b"},
+		{"5 % 2", 5, "%", 2},
+		{"2 ** 8", 2, "**", 8},
+		{"a & b", "a", "&", "b"},
+		{"a | b", "a", "|", "b"},
+		{"a ^ b", "a", "^", "b"},
+		{"1 << 3", 1, "<<", 3},
+		{"8 >> 1", 8, ">>", 1},
 	}
 
 	for _, it := range infixTests {
@@ -225,6 +409,43 @@ func TestParsingOperatorPrecedence(t *testing.T) {
 		{"3 < 5 == true", "((3 < 5) == true)"},
 		{"3 + (4 + 4) * 2", "(3 + ((4 + 4) * 2))"},
 		{"(5 + 5) * 2", "((5 + 5) * 2)"},
+		{"1.5 + 2 * 0.5e1", "(1.5 + (2 * 0.5e1))"},
+		{"x = 1 + 2", "(x = (1 + 2))"},
+		{"x = y = 3", "(x = (y = 3))"},
+		{"x += y -= 2 * 3", "(x += (y -= (2 * 3)))"},
+		{"x = a == b", "(x = (a == b))"},
+		{"a || b && c", "(a || (b && c))"},
+		{"a && b || c && d", "((a && b) || (c && d))"},
+		{"a == b && c != d", "((a == b) && (c != d))"},
+		{"1 < 2 || 3 + 4 > 5", "((1 < 2) || ((3 + 4) > 5))"},
+		{"!a && b", "((!a) && b)"},
+		{"x = a || b", "(x = (a || b))"},
+		{"a ?? b || c", "(a ?? (b || c))"},
+		{"x = a ?? b ?? c", "(x = ((a ?? b) ?? c))"},
+		{"a?.b?.c", "((a?.b)?.c)"},
+		{"a?[0]?.b[1]", "(((a?[0])?.b)[1])"},
+		{"-a?.b", "(-(a?.b))"},
+		{"a?.b ?? null", "((a?.b) ?? null)"},
+		{"f(x)?[y + 1]", "(f(x)?[(y + 1)])"},
+		{"a.b.c", "((a.b).c)"},
+		{"a.b(c).d", "((a.b)(c).d)"},
+		{"a.b[0] + c.d", "(((a.b)[0]) + (c.d))"},
+		{"-a.b", "(-(a.b))"},
+		{"a.b = c.d = 1", "((a.b) = ((c.d) = 1))"},
+		{"a.b += 1", "((a.b) += 1)"},
+		{"2 ** 3 ** 2", "(2 ** (3 ** 2))"},
+		{"-2 ** 2", "(-(2 ** 2))"},
+		{"2 * 3 ** 2", "(2 * (3 ** 2))"},
+		{"a * b % c", "((a * b) % c)"},
+		{"a | b ^ c & d", "(a | (b ^ (c & d)))"},
+		{"a & b == c", "((a & b) == c)"},
+		{"a << 1 + b", "(a << (1 + b))"},
+		{"a < b << c", "(a < (b << c))"},
+		{"~a & b", "((~a) & b)"},
+		{"a | b && c", "((a | b) && c)"},
+		{"f(x = 1)", "f((x = 1))"},
+		{"a[i + 1] = b[0] * 2", "((a[(i + 1)]) = ((b[0]) * 2))"},
+		{"m[0][1] += 1", "(((m[0])[1]) += 1)"},
 		{"2 / (5 + 5)", "(2 / (5 + 5))"},
 		{"-(5 + 5)", "(-(5 + 5))"},
 		{"!(true == true)", "(!(true == true))"},
@@ -385,6 +606,180 @@ func TestParsingIfElseExpression(t *testing.T) {
 	}
 }
 
+func TestParsingWhileStatement(t *testing.T) {
+	input := `while (x < 10) { let x = x + 1; continue; }`
+
+	lexer := lexer.New(input)
+	parser := New(lexer)
+	program := parser.ParserProgram()
+	checkParserErros(t, parser)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("program.Statements does not contain 1 statement, got=%d", len(program.Statements))
+	}
+
+	stmt, ok := program.Statements[0].(*ast.WhileStatement)
+
+	if !ok {
+		t.Fatalf("program.Statements[0] is not *ast.WhileStatement, got=%T", program.Statements[0])
+	}
+
+	if !testInfixExpression(t, "<", "x", 10, stmt.Condition) {
+		return
+	}
+
+	if len(stmt.Body.Statements) != 2 {
+		t.Fatalf("stmt.Body.Statements does not contain 2 statements, got=%d", len(stmt.Body.Statements))
+	}
+
+	if stmt.Body.Statements[0].String() != "let x = (x + 1);" {
+		t.Errorf("stmt.Body.Statements[0] is not %q, got=%q", "let x = (x + 1);", stmt.Body.Statements[0].String())
+	}
+
+	if _, ok := stmt.Body.Statements[1].(*ast.ContinueStatement); !ok {
+		t.Errorf("stmt.Body.Statements[1] is not *ast.ContinueStatement, got=%T", stmt.Body.Statements[1])
+	}
+}
+
+func TestParsingForStatement(t *testing.T) {
+	input := `for (x in [1, 2]) { if (x > 1) { break } x }`
+
+	lexer := lexer.New(input)
+	parser := New(lexer)
+	program := parser.ParserProgram()
+	checkParserErros(t, parser)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("program.Statements does not contain 1 statement, got=%d", len(program.Statements))
+	}
+
+	stmt, ok := program.Statements[0].(*ast.ForStatement)
+
+	if !ok {
+		t.Fatalf("program.Statements[0] is not *ast.ForStatement, got=%T", program.Statements[0])
+	}
+
+	if !testIdentifierExpression(t, "x", stmt.Variable) {
+		return
+	}
+
+	if stmt.Iterable.String() != "[1, 2]" {
+		t.Errorf("stmt.Iterable is not %q, got=%q", "[1, 2]", stmt.Iterable.String())
+	}
+
+	if stmt.String() != "for (x in [1, 2]) (if ((x > 1)) (break;)x)" {
+		t.Errorf("stmt.String() wrong, got=%q", stmt.String())
+	}
+}
+
+func TestParsingLoopControlOutsideLoop(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"break;", []string{`1:1: "break" outside of a loop`}},
+		{"let x = 1;\ncontinue; x", []string{`2:1: "continue" outside of a loop`}},
+		{"while (true) { fn() { break; } }", []string{`1:23: "break" outside of a loop`}},
+		{"for (x in xs) { fn() { continue } }; break", []string{`1:24: "continue" outside of a loop`, `1:38: "break" outside of a loop`}},
+		{"while (true) { for (x in xs) { break } continue }", []string{}},
+		{"for (x) { x }", []string{`1:7: [PARSER] - Failed to parse "IN", got=")"`}},
+	}
+
+	for _, tt := range tests {
+		parser := New(lexer.New(tt.input))
+		parser.ParserProgram()
+
+		errs := parser.Errors()
+
+		if len(errs) != len(tt.expected) {
+			t.Errorf("wrong number of errors for %q, expected=%d, got=%d (%q)", tt.input, len(tt.expected), len(errs), errs)
+			continue
+		}
+
+		for i, err := range errs {
+			if err.Error() != tt.expected[i] {
+				t.Errorf("wrong error, expected=%q, got=%q", tt.expected[i], err.Error())
+			}
+		}
+	}
+}
+
+func TestParsingAssignExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		operator string
+		target   string
+		value    any
+	}{
+		{"x = 5;", "=", "x", 5},
+		{"x += 5;", "+=", "x", 5},
+		{"x -= y;", "-=", "x", "y"},
+		{"x *= 2;", "*=", "x", 2},
+		{"x /= 2;", "/=", "x", 2},
+	}
+
+	for _, tt := range tests {
+		lexer := lexer.New(tt.input)
+		parser := New(lexer)
+		program := parser.ParserProgram()
+		checkParserErros(t, parser)
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("program.Statements does not contain 1 statement, got=%d", len(program.Statements))
+		}
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+
+		assign, ok := stmt.Expression.(*ast.AssignExpression)
+
+		if !ok {
+			t.Fatalf("stmt.Expression is not *ast.AssignExpression, got=%T", stmt.Expression)
+		}
+
+		if assign.Operator != tt.operator {
+			t.Errorf("assign.Operator is not %q, got=%q", tt.operator, assign.Operator)
+		}
+
+		testIdentifierExpression(t, tt.target, assign.Target)
+		testLiteralExpression(t, tt.value, assign.Value)
+	}
+}
+
+func TestParsingInvalidAssignTarget(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"1 = 2;", "1:3: cannot assign to 1"},
+		{"f() = 2;", "1:5: cannot assign to f()"},
+		{"a[0] + 1 = 2;", "1:10: cannot assign to ((a[0]) + 1)"},
+		{"a + b = 2;", "1:7: cannot assign to (a + b)"},
+		{"a?.b = 2;", "1:6: cannot assign to optional access (a?.b)"},
+		{"a?[0] += 2;", "1:7: cannot assign to optional access (a?[0])"},
+		{"a.b() = 2;", "1:7: cannot assign to (a.b)()"},
+	}
+
+	for _, tt := range tests {
+		parser := New(lexer.New(tt.input))
+		parser.ParserProgram()
+
+		errs := parser.Errors()
+
+		if len(errs) != 1 {
+			t.Errorf("expected 1 error for %q, got=%d (%q)", tt.input, len(errs), errs)
+			continue
+		}
+
+		if errs[0].Error() != tt.expected {
+			t.Errorf("wrong error, expected=%q, got=%q", tt.expected, errs[0].Error())
+		}
+
+		if errs[0].Code != INVALID_ASSIGNMENT {
+			t.Errorf("wrong code, expected=%q, got=%q", INVALID_ASSIGNMENT, errs[0].Code)
+		}
+	}
+}
+
 func TestParsingFunctionExpression(t *testing.T) {
 	input := "fn(x,y) { x + y; }"
 
@@ -429,6 +824,36 @@ func TestParsingFunctionExpression(t *testing.T) {
 	testInfixExpression(t, "+", "x", "y", bodyStmt.Expression)
 }
 
+func TestParsingFunctionExpressionName(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let add = fn(x, y) { x + y };", "add"},
+		{"fn(x) { x };", ""},
+	}
+
+	for _, tt := range tests {
+		lexer := lexer.New(tt.input)
+		parser := New(lexer)
+		program := parser.ParserProgram()
+		checkParserErros(t, parser)
+
+		var fn *ast.FunctionExpression
+
+		switch stmt := program.Statements[0].(type) {
+		case *ast.LetStatement:
+			fn = stmt.Value.(*ast.FunctionExpression)
+		case *ast.ExpressionStatement:
+			fn = stmt.Expression.(*ast.FunctionExpression)
+		}
+
+		if fn.Name != tt.expected {
+			t.Errorf("fn.Name is not %q, got=%q", tt.expected, fn.Name)
+		}
+	}
+}
+
 func TestParsingFunctionCallExpression(t *testing.T) {
 	input := "add(x,y);"
 
@@ -489,8 +914,72 @@ func TestParsingStringExpression(t *testing.T) {
 		t.Fatalf("expression.Expression is not *ast.StringExpression, got=%T", stringExpression)
 	}
 
-	if stringExpression.Value != "hello\\nword" {
-		t.Fatalf("stringExpression is not %q, got=%q", "hello\\nword", stringExpression.Value)
+	if stringExpression.Value != "hello\nword" {
+		t.Fatalf("stringExpression is not %q, got=%q", "hello\nword", stringExpression.Value)
+	}
+}
+
+func TestParsingInterpolatedString(t *testing.T) {
+	input := `"Hello ${name}, you have ${len(items) + 1} items"`
+
+	parser := New(lexer.New(input))
+	program := parser.ParserProgram()
+	checkParserErros(t, parser)
+
+	str, ok := program.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.InterpolatedString)
+
+	if !ok {
+		t.Fatalf("expression is not *ast.InterpolatedString, got=%T", program.Statements[0].(*ast.ExpressionStatement).Expression)
+	}
+
+	if len(str.Parts) != 5 {
+		t.Fatalf("str.Parts does not contain 5 parts, got=%d", len(str.Parts))
+	}
+
+	for i, text := range []string{"Hello ", ", you have ", " items"} {
+		part, ok := str.Parts[i*2].(*ast.StringExpression)
+
+		if !ok || part.Value != text {
+			t.Errorf("str.Parts[%d] is not %q, got=%T(%+v)", i*2, text, str.Parts[i*2], str.Parts[i*2])
+		}
+	}
+
+	testIdentifierExpression(t, "name", str.Parts[1])
+
+	if str.Parts[3].String() != "(len(items) + 1)" {
+		t.Errorf("str.Parts[3] wrong, got=%q", str.Parts[3].String())
+	}
+
+	if str.String() != "Hello ${name}, you have ${(len(items) + 1)} items" {
+		t.Errorf("str.String() wrong, got=%q", str.String())
+	}
+}
+
+func TestParsingInterpolatedStringErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`"${}"`, "1:4: a prefix parser function for \"TEMPLATE_TAIL\" not found"},
+		{`"${a b}"`, "1:6: [PARSER] - Failed to parse \"TEMPLATE_TAIL\", got=\"IDENT\""},
+		{`"${a}\q"`, `1:6: invalid escape sequence: \q`},
+		{`"${a`, "1:5: unterminated string interpolation"},
+	}
+
+	for _, tt := range tests {
+		parser := New(lexer.New(tt.input))
+		parser.ParserProgram()
+
+		errs := parser.Errors()
+
+		if len(errs) == 0 {
+			t.Errorf("expected an error for %q", tt.input)
+			continue
+		}
+
+		if errs[0].Error() != tt.expected {
+			t.Errorf("wrong error, expected=%q, got=%q", tt.expected, errs[0].Error())
+		}
 	}
 }
 
@@ -549,6 +1038,167 @@ func TestArrayIndexExpression(t *testing.T) {
 	testInfixExpression(t, "*", 2, 2, arrayIdxExpression.Index)
 }
 
+func TestOptionalIndexExpression(t *testing.T) {
+	parser := New(lexer.New("cfg?[2*2]"))
+	program := parser.ParserProgram()
+	checkParserErros(t, parser)
+
+	indexExpression, ok := program.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.IndexExpression)
+
+	if !ok {
+		t.Fatalf("expression is not *ast.IndexExpression, got=%T", program.Statements[0].(*ast.ExpressionStatement).Expression)
+	}
+
+	if !indexExpression.Optional {
+		t.Errorf("indexExpression.Optional is false")
+	}
+
+	testIdentifierExpression(t, "cfg", indexExpression.Left)
+	testInfixExpression(t, "*", 2, 2, indexExpression.Index)
+}
+
+func TestSliceExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		hasLow   bool
+		hasHigh  bool
+	}{
+		{"arr[1:3]", "(arr[1:3])", true, true},
+		{"arr[:2]", "(arr[:2])", false, true},
+		{"arr[1:]", "(arr[1:])", true, false},
+		{"arr[:]", "(arr[:])", false, false},
+		{"arr[-2:-1]", "(arr[(-2):(-1)])", true, true},
+		{"arr?[i + 1:]", "(arr?[(i + 1):])", true, false},
+		{"f(x)[a:b]", "(f(x)[a:b])", true, true},
+	}
+
+	for _, tt := range tests {
+		parser := New(lexer.New(tt.input))
+		program := parser.ParserProgram()
+		checkParserErros(t, parser)
+
+		slice, ok := program.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.SliceExpression)
+
+		if !ok {
+			t.Fatalf("%s: expression is not *ast.SliceExpression, got=%T", tt.input, program.Statements[0].(*ast.ExpressionStatement).Expression)
+		}
+
+		if slice.String() != tt.expected {
+			t.Errorf("%s: wrong String, expected=%q, got=%q", tt.input, tt.expected, slice.String())
+		}
+
+		if (slice.Low != nil) != tt.hasLow || (slice.High != nil) != tt.hasHigh {
+			t.Errorf("%s: wrong bounds, got low=%v high=%v", tt.input, slice.Low, slice.High)
+		}
+	}
+}
+
+func TestSliceExpressionErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"arr[1:2:3]", `1:8: [PARSER] - Failed to parse "]", got=":"`},
+		{"arr[1:", `1:7: a prefix parser function for "EOF" not found`},
+		{"arr[:] = 1", "1:8: cannot assign to (arr[:])"},
+	}
+
+	for _, tt := range tests {
+		parser := New(lexer.New(tt.input))
+		parser.ParserProgram()
+
+		errs := parser.Errors()
+
+		if len(errs) == 0 {
+			t.Errorf("expected an error for %q", tt.input)
+			continue
+		}
+
+		if errs[0].Error() != tt.expected {
+			t.Errorf("wrong error, expected=%q, got=%q", tt.expected, errs[0].Error())
+		}
+	}
+}
+
+func TestMemberExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		object   string
+		property string
+		optional bool
+	}{
+		{"cfg.db", "cfg", "db", false},
+		{"cfg?.db", "cfg", "db", true},
+	}
+
+	for _, tt := range tests {
+		parser := New(lexer.New(tt.input))
+		program := parser.ParserProgram()
+		checkParserErros(t, parser)
+
+		member, ok := program.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.MemberExpression)
+
+		if !ok {
+			t.Fatalf("expression is not *ast.MemberExpression, got=%T", program.Statements[0].(*ast.ExpressionStatement).Expression)
+		}
+
+		testIdentifierExpression(t, tt.object, member.Object)
+		testIdentifierExpression(t, tt.property, member.Property)
+
+		if member.Optional != tt.optional {
+			t.Errorf("member.Optional is not %t, got=%t", tt.optional, member.Optional)
+		}
+	}
+}
+
+func TestMethodCallExpression(t *testing.T) {
+	parser := New(lexer.New(`"abc".upper(1, x)`))
+	program := parser.ParserProgram()
+	checkParserErros(t, parser)
+
+	call, ok := program.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.CallExpression)
+
+	if !ok {
+		t.Fatalf("expression is not *ast.CallExpression, got=%T", program.Statements[0].(*ast.ExpressionStatement).Expression)
+	}
+
+	member, ok := call.Function.(*ast.MemberExpression)
+
+	if !ok {
+		t.Fatalf("call.Function is not *ast.MemberExpression, got=%T", call.Function)
+	}
+
+	if str, ok := member.Object.(*ast.StringExpression); !ok || str.Value != "abc" {
+		t.Errorf("member.Object is not \"abc\", got=%T(%+v)", member.Object, member.Object)
+	}
+
+	testIdentifierExpression(t, "upper", member.Property)
+
+	if len(call.FunctionCallParameters) != 2 {
+		t.Fatalf("wrong number of arguments, got=%d", len(call.FunctionCallParameters))
+	}
+
+	testLiteralExpression(t, 1, call.FunctionCallParameters[0])
+	testLiteralExpression(t, "x", call.FunctionCallParameters[1])
+}
+
+func TestParsingNullExpression(t *testing.T) {
+	parser := New(lexer.New("null"))
+	program := parser.ParserProgram()
+	checkParserErros(t, parser)
+
+	null, ok := program.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.NullExpression)
+
+	if !ok {
+		t.Fatalf("expression is not *ast.NullExpression, got=%T", program.Statements[0].(*ast.ExpressionStatement).Expression)
+	}
+
+	if null.String() != "null" {
+		t.Errorf("null.String() is not %q, got=%q", "null", null.String())
+	}
+}
+
 func TestHashExpression(t *testing.T) {
 	input := `{"test": 1, "test2": 2, "test3": 3}`
 
@@ -573,22 +1223,32 @@ func TestHashExpression(t *testing.T) {
 		t.Fatalf("hashExpress.Pairs does not contain 3 items, got=%d", len(hashExpress.Pairs))
 	}
 
-	expected := map[string]int64{
-		"test":  1,
-		"test2": 2,
-		"test3": 3,
+	expected := []struct {
+		key   string
+		value int64
+	}{
+		{"test", 1},
+		{"test2", 2},
+		{"test3", 3},
 	}
 
-	for k, v := range hashExpress.Pairs {
-		lit, ok := k.(*ast.StringExpression)
+	for i, pair := range hashExpress.Pairs {
+		lit, ok := pair.Key.(*ast.StringExpression)
 
 		if !ok {
-			t.Errorf("key is not an *ast.StringExpression, got=%T", k)
+			t.Errorf("key is not an *ast.StringExpression, got=%T", pair.Key)
+			continue
+		}
+
+		if lit.String() != expected[i].key {
+			t.Errorf("hashExpress.Pairs[%d] has wrong key, expected=%q, got=%q", i, expected[i].key, lit.String())
 		}
 
-		vtt := expected[lit.String()]
+		testIntegerExpression(t, expected[i].value, pair.Value)
+	}
 
-		testIntegerExpression(t, vtt, v)
+	if hashExpress.String() != "{test:1, test2:2, test3:3}" {
+		t.Errorf("hashExpress.String() wrong, got=%q", hashExpress.String())
 	}
 }
 
@@ -628,11 +1288,11 @@ func TestHashExpressionWithInfixExpression(t *testing.T) {
 		},
 	}
 
-	for k, v := range hashExpress.Pairs {
-		lit, ok := k.(*ast.StringExpression)
+	for _, pair := range hashExpress.Pairs {
+		lit, ok := pair.Key.(*ast.StringExpression)
 
 		if !ok {
-			t.Errorf("key is not an *ast.StringExpression, got=%T", k)
+			t.Errorf("key is not an *ast.StringExpression, got=%T", pair.Key)
 			continue
 		}
 
@@ -643,7 +1303,7 @@ func TestHashExpressionWithInfixExpression(t *testing.T) {
 			continue
 		}
 
-		f(v)
+		f(pair.Value)
 	}
 }
 
@@ -672,6 +1332,259 @@ func TestHashExpressionEmpty(t *testing.T) {
 	}
 }
 
+func TestNodePositions(t *testing.T) {
+	input := `let add = fn(x, y) {
+  x + y;
+};
+add(1,
+    [2, 3][0]);`
+
+	lexer := lexer.New(input)
+	parser := New(lexer)
+	program := parser.ParserProgram()
+	checkParserErros(t, parser)
+
+	if len(program.Statements) != 2 {
+		t.Fatalf("program.Statements does not contain 2 statements, got=%d", len(program.Statements))
+	}
+
+	letStmt := program.Statements[0].(*ast.LetStatement)
+	fnExpress := letStmt.Value.(*ast.FunctionExpression)
+	infixExpress := fnExpress.Body.Statements[0].(*ast.ExpressionStatement).Expression
+	callExpress := program.Statements[1].(*ast.ExpressionStatement).Expression.(*ast.CallExpression)
+	indexExpress := callExpress.FunctionCallParameters[1]
+
+	tests := []struct {
+		node          ast.Node
+		expectedStart string
+		expectedEnd   string
+	}{
+		{program, "1:1", "5:16"},
+		{letStmt, "1:1", "3:3"},
+		{letStmt.Name, "1:5", "1:8"},
+		{fnExpress, "1:11", "3:2"},
+		{fnExpress.Body, "1:20", "3:2"},
+		{infixExpress, "2:3", "2:8"},
+		{callExpress, "4:1", "5:15"},
+		{indexExpress, "5:5", "5:14"},
+	}
+
+	for i, tt := range tests {
+		if tt.node.Pos().String() != tt.expectedStart {
+			t.Errorf("tests[%d] - %T.Pos() wrong. expected=%q, got=%q", i, tt.node, tt.expectedStart, tt.node.Pos())
+		}
+
+		if tt.node.End().String() != tt.expectedEnd {
+			t.Errorf("tests[%d] - %T.End() wrong. expected=%q, got=%q", i, tt.node, tt.expectedEnd, tt.node.End())
+		}
+	}
+}
+
+func TestParserErrorPositions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let x 5;", `1:7: [PARSER] - Failed to parse "=", got="INT"`},
+		{"let x = 5;\nlet = 10;", `2:5: [PARSER] - Failed to parse "IDENT", got="="`},
+		{"let x = 1;\n\n  x + ;", `3:7: a prefix parser function for ";" not found`},
+		{`let s = "open`, "1:9: unterminated string literal"},
+		{`let s = "a\tb\qc";`, `1:14: invalid escape sequence: \q`},
+		{"let s = `raw", "1:9: unterminated raw string literal"},
+		{"let x = 1; /* open", "1:12: unterminated block comment"},
+	}
+
+	for _, tt := range tests {
+		parser := New(lexer.New(tt.input))
+		parser.ParserProgram()
+
+		errs := parser.Errors()
+
+		if len(errs) == 0 {
+			t.Errorf("expected parser errors for %q, got none", tt.input)
+			continue
+		}
+
+		if errs[0].Error() != tt.expected {
+			t.Errorf("wrong error, expected=%q, got=%q", tt.expected, errs[0].Error())
+		}
+	}
+
+	parser := New(lexer.NewWithFilename("script.mk", "let x = 5;\nlet y 6;"))
+	parser.ParserProgram()
+
+	if errs := parser.Errors(); len(errs) == 0 || errs[0].Error() != `script.mk:2:7: [PARSER] - Failed to parse "=", got="INT"` {
+		t.Errorf("wrong errors with filename, got=%q", errs)
+	}
+}
+
+func TestParserErrorRecovery(t *testing.T) {
+	tests := []struct {
+		input              string
+		expectedErrors     []string
+		expectedStatements []string
+	}{
+		{
+			"let x 5; let y = 10; y;",
+			[]string{`1:7: [PARSER] - Failed to parse "=", got="INT"`},
+			[]string{"let y = 10;", "y"},
+		},
+		{
+			"let x = (1 + ; let y = 2;",
+			[]string{`1:14: a prefix parser function for ";" not found`},
+			[]string{"let y = 2;"},
+		},
+		{
+			"let x 5 let y = 2;",
+			[]string{`1:7: [PARSER] - Failed to parse "=", got="INT"`},
+			[]string{"let y = 2;"},
+		},
+		{
+			"if (x { 1 }; let y = 2;",
+			[]string{`1:7: [PARSER] - Failed to parse ")", got="{"`},
+			[]string{"let y = 2;"},
+		},
+		{
+			"let f = fn(x) { let = 1; x }; f(2);",
+			[]string{`1:21: [PARSER] - Failed to parse "IDENT", got="="`},
+			[]string{"let f = fn(x) x;", "f(2)"},
+		},
+		{
+			`let h = {"a": 1, "b" 2}; puts(h);`,
+			[]string{`1:22: [PARSER] - Failed to parse ":", got="INT"`},
+			[]string{"puts(h)"},
+		},
+		{
+			`let h = {"a": {"b": {"c" 1}}, "d": 2}; h;`,
+			[]string{`1:26: [PARSER] - Failed to parse ":", got="INT"`},
+			[]string{"h"},
+		},
+		{
+			`let f = fn() { let h = {"a" 1}; h }; f();`,
+			[]string{`1:29: [PARSER] - Failed to parse ":", got="INT"`},
+			[]string{"let f = fn() h;", "f()"},
+		},
+		{
+			`let h = {"f": fn() { let x = {1 2}; x }, "g" 3}; h;`,
+			[]string{`1:33: [PARSER] - Failed to parse ":", got="INT"`, `1:46: [PARSER] - Failed to parse ":", got="INT"`},
+			[]string{"h"},
+		},
+		{
+			"map(fn(x) { x + }, [1, 2]);\nlet g = 2 +;\nlet k = 3;",
+			[]string{
+				`1:17: a prefix parser function for "}" not found`,
+				`2:12: a prefix parser function for ";" not found`,
+			},
+			[]string{"map(fn(x) , [1, 2])", "let k = 3;"},
+		},
+		{
+			"let a = [1, if (x) { 1 + }, 3];\nlet k = 3;",
+			[]string{`1:26: a prefix parser function for "}" not found`},
+			[]string{"let a = [1, if (x) (), 3];", "let k = 3;"},
+		},
+		{
+			`let h = {"a": if (x) { 1 + }, "b": 2}; let k = 3;`,
+			[]string{`1:28: a prefix parser function for "}" not found`},
+			[]string{"let h = {a:if (x) (), b:2};", "let k = 3;"},
+		},
+		{
+			"let a = ];\nlet b = 2;\nlet c = );",
+			[]string{
+				`1:9: a prefix parser function for "]" not found`,
+				`3:9: a prefix parser function for ")" not found`,
+			},
+			[]string{"let b = 2;"},
+		},
+	}
+
+	for _, tt := range tests {
+		parser := New(lexer.New(tt.input))
+		program := parser.ParserProgram()
+
+		errs := parser.Errors()
+
+		if len(errs) != len(tt.expectedErrors) {
+			t.Errorf("wrong number of errors for %q, expected=%d, got=%d (%q)", tt.input, len(tt.expectedErrors), len(errs), errs)
+			continue
+		}
+
+		for i, err := range errs {
+			if err.Error() != tt.expectedErrors[i] {
+				t.Errorf("wrong error, expected=%q, got=%q", tt.expectedErrors[i], err.Error())
+			}
+		}
+
+		if len(program.Statements) != len(tt.expectedStatements) {
+			t.Errorf("wrong number of statements for %q, expected=%d, got=%d", tt.input, len(tt.expectedStatements), len(program.Statements))
+			continue
+		}
+
+		for i, stmt := range program.Statements {
+			if stmt.String() != tt.expectedStatements[i] {
+				t.Errorf("wrong statement, expected=%q, got=%q", tt.expectedStatements[i], stmt.String())
+			}
+		}
+	}
+}
+
+func TestParserErrorFields(t *testing.T) {
+	parser := New(lexer.New("let x = 1;\nlet y 2;"))
+	parser.ParserProgram()
+
+	errs := parser.Errors()
+
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got=%d", len(errs))
+	}
+
+	err := errs[0]
+
+	if err.Pos.Line != 2 || err.Pos.Column != 7 {
+		t.Errorf("err.Pos wrong, expected=%q, got=%q", "2:7", err.Pos)
+	}
+
+	if err.Expected != token.ASSIGN {
+		t.Errorf("err.Expected wrong, expected=%q, got=%q", token.ASSIGN, err.Expected)
+	}
+
+	if err.Got != token.INT {
+		t.Errorf("err.Got wrong, expected=%q, got=%q", token.INT, err.Got)
+	}
+
+	if err.Message != `[PARSER] - Failed to parse "=", got="INT"` {
+		t.Errorf("err.Message wrong, got=%q", err.Message)
+	}
+}
+
+func TestParserErrorDiagnostic(t *testing.T) {
+	parser := New(lexer.New("let x = 1;\nlet y = );"))
+	parser.ParserProgram()
+
+	errs := parser.Errors()
+
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got=%d", len(errs))
+	}
+
+	d := errs[0].Diagnostic()
+
+	if d.Severity != diagnostics.ERROR {
+		t.Errorf("d.Severity wrong, expected=%q, got=%q", diagnostics.ERROR, d.Severity)
+	}
+
+	if d.Code != NO_PREFIX_PARSER {
+		t.Errorf("d.Code wrong, expected=%q, got=%q", NO_PREFIX_PARSER, d.Code)
+	}
+
+	if d.Span.Start.String() != "2:9" || d.Span.End.String() != "2:10" {
+		t.Errorf("d.Span wrong, expected=%q-%q, got=%q-%q", "2:9", "2:10", d.Span.Start, d.Span.End)
+	}
+
+	if d.Message != errs[0].Message {
+		t.Errorf("d.Message wrong, expected=%q, got=%q", errs[0].Message, d.Message)
+	}
+}
+
 func checkParserErros(t *testing.T, parser *Parser) {
 	errs := parser.Errors()
 
@@ -743,6 +1656,22 @@ func testIntegerExpression(t *testing.T, testIntegerValue int64, expression ast.
 	return true
 }
 
+func testFloatExpression(t *testing.T, testFloatValue float64, expression ast.Expression) bool {
+	float, ok := expression.(*ast.FloatExpression)
+
+	if !ok {
+		t.Errorf("expression is not *ast.FloatExpression, got=%T", expression)
+		return false
+	}
+
+	if float.Value != testFloatValue {
+		t.Errorf("float.Value is not %g, got=%g", testFloatValue, float.Value)
+		return false
+	}
+
+	return true
+}
+
 func testIdentifierExpression(t *testing.T, value string, expression ast.Expression) bool {
 	identifier, ok := expression.(*ast.Identifier)
 
@@ -816,6 +1745,8 @@ func testLiteralExpression(t *testing.T, value any, expression ast.Expression) b
 		return testIntegerExpression(t, int64(v), expression)
 	case int64:
 		return testIntegerExpression(t, v, expression)
+	case float64:
+		return testFloatExpression(t, v, expression)
 	case string:
 		return testIdentifierExpression(t, v, expression)
 	case bool:
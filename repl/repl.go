@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/rodmedeiross/monkey-interpreter/diagnostics"
 	"github.com/rodmedeiross/monkey-interpreter/evaluator"
 	"github.com/rodmedeiross/monkey-interpreter/lexer"
 	"github.com/rodmedeiross/monkey-interpreter/object"
@@ -18,7 +19,11 @@ func Start(in io.Reader, out io.Writer) {
 	scanner := bufio.NewScanner(in)
 	env := object.NewEnvironment()
 
-	for {
+	// Every input is named <repl:N> and kept, because a function defined in
+	// one input can fail while a later one runs.
+	history := make(map[string]string)
+
+	for n := 1; ; n++ {
 		fmt.Printf(PROMPT)
 		scanned := scanner.Scan()
 
@@ -27,18 +32,27 @@ func Start(in io.Reader, out io.Writer) {
 		}
 
 		line := scanner.Text()
-		l := lexer.New(line)
+		filename := fmt.Sprintf("<repl:%d>", n)
+		history[filename] = line
+
+		l := lexer.NewWithFilename(filename, line)
 		p := parser.New(l)
 
 		program := p.ParserProgram()
 
 		if len(p.Errors()) != 0 {
-			printParserErrors(out, p.Errors())
+			printParserErrors(out, line, p.Errors())
 			continue
 		}
 
 		evaluated := evaluator.Eval(program, env)
 
+		if err, ok := evaluated.(*object.Error); ok {
+			d := err.Diagnostic()
+			diagnostics.Render(out, history[d.Span.Start.Filename], d)
+			continue
+		}
+
 		if evaluated != nil {
 			//io.WriteString(out, program.String())
 			//io.WriteString(out, "\n")
@@ -52,10 +66,9 @@ func Start(in io.Reader, out io.Writer) {
 	}
 }
 
-func printParserErrors(out io.Writer, errors []string) {
+func printParserErrors(out io.Writer, source string, errors []*parser.ParserError) {
 	io.WriteString(out, "Woops! We ran into some monkey business here!\n")
-	io.WriteString(out, " parser errors:\n")
-	for _, msg := range errors {
-		io.WriteString(out, "\t"+msg+"\n")
+	for _, err := range errors {
+		diagnostics.Render(out, source, err.Diagnostic())
 	}
 }
@@ -1,17 +1,54 @@
 package token
 
+import "fmt"
+
 type TokenType string
 
+// Position describes a location in the source. Line and Column are 1-based,
+// Offset is the 0-based byte offset into the input.
+type Position struct {
+	Filename string
+	Offset   int
+	Line     int
+	Column   int
+}
+
+// IsValid reports whether the position was set by the lexer.
+func (p Position) IsValid() bool { return p.Line > 0 }
+
+func (p Position) String() string {
+	s := p.Filename
+
+	if p.IsValid() {
+		if s != "" {
+			s += ":"
+		}
+		s += fmt.Sprintf("%d:%d", p.Line, p.Column)
+	}
+
+	if s == "" {
+		s = "-"
+	}
+
+	return s
+}
+
+// Token carries its Start position and its End position, which points to the
+// character immediately after the token.
 type Token struct {
 	Type    TokenType
 	Literal string
+	Start   Position
+	End     Position
 }
 
 const (
 	ILLEGAL   = "ILLEGAL"
 	EOF       = "EOF"
+	COMMENT   = "COMMENT"
 	IDENT     = "IDENT"
 	INT       = "INT"
+	FLOAT     = "FLOAT"
 	ASSIGN    = "="
 	PLUS      = "+"
 	MINUS     = "-"
@@ -20,7 +57,6 @@ const (
 	SLASH     = "/"
 	LT        = "<"
 	GT        = ">"
-	HASH      = "#"
 	COMMA     = ","
 	SEMICOLON = ";"
 	LPAREN    = "("
@@ -41,18 +77,56 @@ const (
 	NOT_EQ    = "!="
 	GT_EQ     = ">="
 	LT_EQ     = "<="
+	AND       = "&&"
+	OR        = "||"
+	PERCENT   = "%"
+	POWER     = "**"
+	AMPERSAND = "&"
+	PIPE      = "|"
+	CARET     = "^"
+	TILDE     = "~"
+	SHL       = "<<"
+	SHR       = ">>"
 	RETURN    = "RETURN"
+	WHILE     = "WHILE"
+	FOR       = "FOR"
+	IN        = "IN"
+	BREAK     = "BREAK"
+	CONTINUE  = "CONTINUE"
 	STRING    = "STRING"
+
+	PLUS_ASSIGN     = "+="
+	MINUS_ASSIGN    = "-="
+	ASTERISK_ASSIGN = "*="
+	SLASH_ASSIGN    = "/="
+
+	TEMPLATE_HEAD   = "TEMPLATE_HEAD"
+	TEMPLATE_MIDDLE = "TEMPLATE_MIDDLE"
+	TEMPLATE_TAIL   = "TEMPLATE_TAIL"
+
+	NULL          = "NULL"
+	NULLISH       = "??"
+	QUESTION_DOT  = "?."
+	QUESTION_LCOL = "?["
+
+	// ERROR is a token the lexer could not read, its Literal the reason why.
+	ERROR = "ERROR"
 )
 
 var keywords = map[string]TokenType{
-	"fn":     FUNCTION,
-	"let":    LET,
-	"false":  FALSE,
-	"true":   TRUE,
-	"if":     IF,
-	"else":   ELSE,
-	"return": RETURN,
+	"fn":       FUNCTION,
+	"let":      LET,
+	"false":    FALSE,
+	"true":     TRUE,
+	"if":       IF,
+	"else":     ELSE,
+	"return":   RETURN,
+	"while":    WHILE,
+	"for":      FOR,
+	"in":       IN,
+	"break":    BREAK,
+	"continue": CONTINUE,
+	"null":     NULL,
 }
 
 func LookupIdent(ident string) TokenType {